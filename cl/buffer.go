@@ -10,6 +10,7 @@ package cl
 */
 import "C"
 import (
+	"fmt"
 	"unsafe"
 )
 
@@ -49,6 +50,15 @@ func CreateSubBuffer(buffer MemObject, flags UInt, bufferCreateType UInt, buffer
 	return MemObject(subBuffer), nil
 }
 
+// CreateSubBufferRegion 按区域创建子缓冲区，region.Origin 需满足设备的基地址对齐要求
+func CreateSubBufferRegion(buffer MemObject, flags UInt, region BufferRegion) (MemObject, error) {
+	info := C.cl_buffer_region{
+		origin: C.size_t(region.Origin),
+		size:   C.size_t(region.Size),
+	}
+	return CreateSubBuffer(buffer, flags, BufferCreateTypeRegion, unsafe.Pointer(&info))
+}
+
 func ReleaseMemObject(memObj MemObject) error {
 	err := C.clReleaseMemObject(C.cl_mem(memObj))
 	if err != C.CL_SUCCESS {
@@ -233,6 +243,208 @@ func EnqueueCopyBuffer(queue CommandQueue, srcBuffer MemObject, dstBuffer MemObj
 	return Event(event), nil
 }
 
+// EnqueueReadBufferRect 从缓冲区读取二维或三维矩形区域
+// bufferOrigin/hostOrigin 以 (字节, 行, 切片) 表示，region 以 (字节宽度, 行数, 切片数) 表示；
+// 行间距与切片间距为 0 时按 region 紧密排列计算。
+func EnqueueReadBufferRect(queue CommandQueue, buffer MemObject, blocking Bool, bufferOrigin [3]Size, hostOrigin [3]Size, region [3]Size, bufferRowPitch Size, bufferSlicePitch Size, hostRowPitch Size, hostSlicePitch Size, ptr unsafe.Pointer, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if err := requireQueueVersion(queue, 1, 1, "clEnqueueReadBufferRect"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	bufferOriginArray := [3]C.size_t{C.size_t(bufferOrigin[0]), C.size_t(bufferOrigin[1]), C.size_t(bufferOrigin[2])}
+	hostOriginArray := [3]C.size_t{C.size_t(hostOrigin[0]), C.size_t(hostOrigin[1]), C.size_t(hostOrigin[2])}
+	regionArray := [3]C.size_t{C.size_t(region[0]), C.size_t(region[1]), C.size_t(region[2])}
+
+	err = C.clEnqueueReadBufferRect(
+		C.cl_command_queue(queue),
+		C.cl_mem(buffer),
+		C.cl_bool(blocking),
+		&bufferOriginArray[0],
+		&hostOriginArray[0],
+		&regionArray[0],
+		C.size_t(bufferRowPitch),
+		C.size_t(bufferSlicePitch),
+		C.size_t(hostRowPitch),
+		C.size_t(hostSlicePitch),
+		ptr,
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueWriteBufferRect 向缓冲区写入二维或三维矩形区域
+// bufferOrigin/hostOrigin 以 (字节, 行, 切片) 表示，region 以 (字节宽度, 行数, 切片数) 表示；
+// 行间距与切片间距为 0 时按 region 紧密排列计算。
+func EnqueueWriteBufferRect(queue CommandQueue, buffer MemObject, blocking Bool, bufferOrigin [3]Size, hostOrigin [3]Size, region [3]Size, bufferRowPitch Size, bufferSlicePitch Size, hostRowPitch Size, hostSlicePitch Size, ptr unsafe.Pointer, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if err := requireQueueVersion(queue, 1, 1, "clEnqueueWriteBufferRect"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	bufferOriginArray := [3]C.size_t{C.size_t(bufferOrigin[0]), C.size_t(bufferOrigin[1]), C.size_t(bufferOrigin[2])}
+	hostOriginArray := [3]C.size_t{C.size_t(hostOrigin[0]), C.size_t(hostOrigin[1]), C.size_t(hostOrigin[2])}
+	regionArray := [3]C.size_t{C.size_t(region[0]), C.size_t(region[1]), C.size_t(region[2])}
+
+	err = C.clEnqueueWriteBufferRect(
+		C.cl_command_queue(queue),
+		C.cl_mem(buffer),
+		C.cl_bool(blocking),
+		&bufferOriginArray[0],
+		&hostOriginArray[0],
+		&regionArray[0],
+		C.size_t(bufferRowPitch),
+		C.size_t(bufferSlicePitch),
+		C.size_t(hostRowPitch),
+		C.size_t(hostSlicePitch),
+		ptr,
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueCopyBufferRect 在两个缓冲区之间复制二维或三维矩形区域
+// 原点以 (字节, 行, 切片) 表示，region 以 (字节宽度, 行数, 切片数) 表示。
+func EnqueueCopyBufferRect(queue CommandQueue, srcBuffer MemObject, dstBuffer MemObject, srcOrigin [3]Size, dstOrigin [3]Size, region [3]Size, srcRowPitch Size, srcSlicePitch Size, dstRowPitch Size, dstSlicePitch Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if err := requireQueueVersion(queue, 1, 1, "clEnqueueCopyBufferRect"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	srcOriginArray := [3]C.size_t{C.size_t(srcOrigin[0]), C.size_t(srcOrigin[1]), C.size_t(srcOrigin[2])}
+	dstOriginArray := [3]C.size_t{C.size_t(dstOrigin[0]), C.size_t(dstOrigin[1]), C.size_t(dstOrigin[2])}
+	regionArray := [3]C.size_t{C.size_t(region[0]), C.size_t(region[1]), C.size_t(region[2])}
+
+	err = C.clEnqueueCopyBufferRect(
+		C.cl_command_queue(queue),
+		C.cl_mem(srcBuffer),
+		C.cl_mem(dstBuffer),
+		&srcOriginArray[0],
+		&dstOriginArray[0],
+		&regionArray[0],
+		C.size_t(srcRowPitch),
+		C.size_t(srcSlicePitch),
+		C.size_t(dstRowPitch),
+		C.size_t(dstSlicePitch),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueFillBuffer 用 pattern 重复填充缓冲区中从 offset 开始的 size 字节
+// pattern 长度需为 1、2、4、8、16、32、64 或 128，offset 与 size 需为其整数倍。
+func EnqueueFillBuffer(queue CommandQueue, buffer MemObject, pattern []byte, offset Size, size Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if !isFillPatternSize(len(pattern)) {
+		return Event(nil), fmt.Errorf("cl: fill pattern is %d bytes, want 1, 2, 4, 8, 16, 32, 64 or 128: %w",
+			len(pattern), OpenCLError{Code: Int(C.CL_INVALID_VALUE)})
+	}
+	if err := requireQueueVersion(queue, 1, 2, "clEnqueueFillBuffer"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	err = C.clEnqueueFillBuffer(
+		C.cl_command_queue(queue),
+		C.cl_mem(buffer),
+		unsafe.Pointer(&pattern[0]),
+		C.size_t(len(pattern)),
+		C.size_t(offset),
+		C.size_t(size),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// FillBuffer 用 value 填充缓冲区中从 offset 开始的 size 字节，T 的大小需满足 EnqueueFillBuffer 对 pattern 的要求，
+// 否则返回包装了 ErrInvalidElementType 的错误
+func FillBuffer[T any](queue CommandQueue, buffer MemObject, value T, offset Size, size Size, eventWaitList []Event) (Event, error) {
+	if _, err := fillPatternSize[T](); err != nil {
+		return Event(nil), err
+	}
+	pattern := unsafe.Slice((*byte)(unsafe.Pointer(&value)), unsafe.Sizeof(value))
+	return EnqueueFillBuffer(queue, buffer, pattern, offset, size, eventWaitList)
+}
+
 func EnqueueMapBuffer(queue CommandQueue, buffer MemObject, blocking Bool, mapFlags UInt, offset Size, size Size, eventWaitList []Event) (unsafe.Pointer, Event, error) {
 	var err C.cl_int
 	var event C.cl_event
@@ -298,3 +510,57 @@ func EnqueueUnmapMemObject(queue CommandQueue, memObj MemObject, mappedPtr unsaf
 
 	return Event(event), nil
 }
+
+// EnqueueMigrateMemObjects 把内存对象迁移到命令队列所在的设备
+// 参数:
+//   - queue: 命令队列，内存对象会迁移到该队列关联的设备
+//   - memObjects: 需要迁移的内存对象
+//   - flags: 迁移标志，MigrateHost 表示迁移到主机，MigrateContentUndefined 表示无需保留原有内容
+//   - eventWaitList: 等待事件列表
+//
+// 返回:
+//   - Event: 迁移命令对应的事件
+//   - error: 错误信息
+func EnqueueMigrateMemObjects(queue CommandQueue, memObjects []MemObject, flags UInt, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if len(memObjects) == 0 {
+		return Event(nil), OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+	if err := requireQueueVersion(queue, 1, 2, "clEnqueueMigrateMemObjects"); err != nil {
+		return Event(nil), err
+	}
+
+	memArray := make([]C.cl_mem, len(memObjects))
+	for i, m := range memObjects {
+		memArray[i] = C.cl_mem(m)
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	err = C.clEnqueueMigrateMemObjects(
+		C.cl_command_queue(queue),
+		C.cl_uint(len(memArray)),
+		&memArray[0],
+		C.cl_mem_migration_flags(flags),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
@@ -0,0 +1,201 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// 诊断级别
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityNote    = "note"
+	SeverityRemark  = "remark"
+)
+
+// Diagnostic 从构建日志中解析出的一条诊断信息
+type Diagnostic struct {
+	File     string // 源文件名，内联源码通常为 "<kernel>"、"<source>" 或临时文件路径；没有位置的诊断（如无效的构建选项）为空
+	Line     int    // 行号，从 1 开始，没有位置时为 0
+	Column   int    // 列号，从 1 开始，日志中没有列号时为 0
+	Severity string // SeverityError、SeverityWarning、SeverityNote 或 SeverityRemark
+	Message  string
+}
+
+// String 返回 "文件:行:列: 级别: 消息" 形式的字符串，没有位置时返回 "级别: 消息"
+func (d Diagnostic) String() string {
+	if d.File == "" && d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+	}
+	if d.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s: %s", d.File, d.Line, d.Column, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", d.File, d.Line, d.Severity, d.Message)
+}
+
+// ProgramBuildError 程序构建（或编译）失败的错误，包含构建日志与解析出的诊断信息
+// 可通过 errors.As 取得；Unwrap 返回底层的 OpenCLError。
+type ProgramBuildError struct {
+	Err         error        // 底层错误，通常为 CL_BUILD_PROGRAM_FAILURE 或 CL_COMPILE_PROGRAM_FAILURE
+	Device      DeviceID     // 第一个构建失败的设备，其他设备的日志可通过 GetProgramBuildLog 获取
+	Log         string       // 该设备的原始构建日志
+	Diagnostics []Diagnostic // 从 Log 中解析出的诊断信息
+}
+
+func (e *ProgramBuildError) Error() string {
+	var b strings.Builder
+	b.WriteString("cl: program build failed")
+
+	errs := e.Errors()
+	if len(errs) > 0 {
+		b.WriteString(": ")
+		b.WriteString(errs[0].String())
+		if len(errs) > 1 {
+			fmt.Fprintf(&b, " (and %d more errors)", len(errs)-1)
+		}
+	} else if e.Err != nil {
+		b.WriteString(": ")
+		b.WriteString(e.Err.Error())
+	}
+	return b.String()
+}
+
+func (e *ProgramBuildError) Unwrap() error {
+	return e.Err
+}
+
+// Errors 返回级别为 error 的诊断信息
+func (e *ProgramBuildError) Errors() []Diagnostic {
+	var errs []Diagnostic
+	for _, d := range e.Diagnostics {
+		if d.Severity == SeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errs
+}
+
+// errBuildProgramFailure 构建失败时 ProgramBuildError 默认包装的错误
+var errBuildProgramFailure = OpenCLError{Code: Int(C.CL_BUILD_PROGRAM_FAILURE)}
+
+// newProgramBuildError 查找第一个构建失败的设备并解析其构建日志
+// 查询失败时仍返回 ProgramBuildError，只是不包含日志。
+func newProgramBuildError(program Program, devices []DeviceID, err error) *ProgramBuildError {
+	buildErr := &ProgramBuildError{Err: err}
+
+	if len(devices) == 0 {
+		devices, _ = GetProgramDevices(program)
+	}
+	for _, device := range devices {
+		status, statusErr := GetProgramBuildStatus(program, device)
+		if statusErr != nil || Int(status) != BuildStatusError {
+			continue
+		}
+		buildErr.Device = device
+		buildErr.Log, _ = GetProgramBuildLog(program, device)
+		buildErr.Diagnostics = ParseBuildLog(buildErr.Log)
+		break
+	}
+
+	return buildErr
+}
+
+var (
+	// Clang 风格（Intel、AMD ROCm、PoCL、NVIDIA、Apple）:
+	//   <kernel>:3:17: error: use of undeclared identifier 'x'
+	//   /tmp/comgr-1a2b/input/CompileSource:5:1: warning: ...
+	clangDiagnostic = regexp.MustCompile(`^(.*?):(\d+):(?:(\d+):)?\s*(fatal error|error|warning|note|remark):\s*(.*)$`)
+
+	// EDG 风格（旧版 NVIDIA）:
+	//   <kernel>(3): error: identifier "x" is undefined
+	edgDiagnostic = regexp.MustCompile(`^(.*?)\((\d+)\):\s*(catastrophic error|error|warning|remark)(?:\s+#[\w-]+)?:\s*(.*)$`)
+
+	// 旧版 AMD APP SDK:
+	//   "/tmp/OCL1234.cl", line 3: error #20: identifier "x" is undefined
+	amdDiagnostic = regexp.MustCompile(`^"(.*?)", line (\d+):\s*(catastrophic error|error|warning|remark)(?:\s+#[\w-]+)?:\s*(.*)$`)
+
+	// 没有源码位置的诊断（无效的构建选项、后端汇编器错误等）:
+	//   error: unknown argument: '-cl-foo'
+	//   ptxas application ptx input, line 12; error   : Unknown symbol 'bar'
+	// 前缀只允许工具名一类的简单文本，避免把回显的源码（如 printf("error: ...")）当作诊断。
+	unlocatedDiagnostic = regexp.MustCompile(`^(?:(\w[\w .,;/-]*?)[\s;,:]+)?(fatal error|error|warning)\s*:\s*(\S.*)$`)
+)
+
+// ParseBuildLog 从编译器日志中解析诊断信息
+// 支持常见的 Clang 风格日志（Intel、AMD、PoCL、NVIDIA）以及旧版 NVIDIA/AMD 的 EDG 风格日志，
+// 没有源码位置的 error/warning 行返回 File 为空、Line 为 0 的诊断；
+// 无法识别的行（源码片段、插入符号行、汇总信息等）会被忽略。
+func ParseBuildLog(log string) []Diagnostic {
+	var diagnostics []Diagnostic
+
+	for _, line := range strings.Split(log, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			continue
+		}
+
+		if m := clangDiagnostic.FindStringSubmatch(line); m != nil {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     m[1],
+				Line:     atoiOrZero(m[2]),
+				Column:   atoiOrZero(m[3]),
+				Severity: normalizeSeverity(m[4]),
+				Message:  strings.TrimSpace(m[5]),
+			})
+			continue
+		}
+
+		m := edgDiagnostic.FindStringSubmatch(line)
+		if m == nil {
+			m = amdDiagnostic.FindStringSubmatch(line)
+		}
+		if m != nil {
+			diagnostics = append(diagnostics, Diagnostic{
+				File:     m[1],
+				Line:     atoiOrZero(m[2]),
+				Severity: normalizeSeverity(m[3]),
+				Message:  strings.TrimSpace(m[4]),
+			})
+			continue
+		}
+
+		if m := unlocatedDiagnostic.FindStringSubmatch(line); m != nil {
+			message := strings.TrimSpace(m[3])
+			if prefix := strings.TrimSpace(m[1]); prefix != "" {
+				message = prefix + ": " + message
+			}
+			diagnostics = append(diagnostics, Diagnostic{
+				Severity: normalizeSeverity(m[2]),
+				Message:  message,
+			})
+		}
+	}
+
+	return diagnostics
+}
+
+// normalizeSeverity 把各厂商的级别名称统一为 Severity* 常量
+func normalizeSeverity(severity string) string {
+	switch severity {
+	case "fatal error", "catastrophic error":
+		return SeverityError
+	default:
+		return severity
+	}
+}
+
+func atoiOrZero(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
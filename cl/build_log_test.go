@@ -0,0 +1,196 @@
+package cl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBuildLog(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+		want []Diagnostic
+	}{
+		{
+			name: "Intel CPU runtime",
+			log: "Compilation started\n" +
+				"1:3:17: error: use of undeclared identifier 'x'\n" +
+				"    out[i] = x;\n" +
+				"             ^\n" +
+				"1:2:9: warning: unused variable 'tmp'\n" +
+				"    int tmp;\n" +
+				"        ^\n" +
+				"1 warning and 1 error generated.\n" +
+				"\n" +
+				"Compilation failed\n",
+			want: []Diagnostic{
+				{File: "1", Line: 3, Column: 17, Severity: SeverityError, Message: "use of undeclared identifier 'x'"},
+				{File: "1", Line: 2, Column: 9, Severity: SeverityWarning, Message: "unused variable 'tmp'"},
+			},
+		},
+		{
+			name: "AMD ROCm comgr",
+			log: "/tmp/comgr-6f2a1b/input/CompileSource:3:17: error: use of undeclared identifier 'x'\n" +
+				"    out[i] = x;\n" +
+				"             ^\n" +
+				"1 error generated.\n" +
+				"Error: Failed to compile source (from CL or HIP source to LLVM IR).\n",
+			want: []Diagnostic{
+				{File: "/tmp/comgr-6f2a1b/input/CompileSource", Line: 3, Column: 17, Severity: SeverityError, Message: "use of undeclared identifier 'x'"},
+			},
+		},
+		{
+			name: "PoCL with multi-line note",
+			log: "<program source>:5:9: error: no matching function for call to 'foo'\n" +
+				"    foo(1.0f);\n" +
+				"    ^~~\n" +
+				"<program source>:1:6: note: candidate function not viable: requires 2 arguments, but 1 was provided\n" +
+				"void foo(float a, float b) {}\n" +
+				"     ^\n" +
+				"1 error generated.\n",
+			want: []Diagnostic{
+				{File: "<program source>", Line: 5, Column: 9, Severity: SeverityError, Message: "no matching function for call to 'foo'"},
+				{File: "<program source>", Line: 1, Column: 6, Severity: SeverityNote, Message: "candidate function not viable: requires 2 arguments, but 1 was provided"},
+			},
+		},
+		{
+			name: "NVIDIA clang style with fatal error and CRLF",
+			log: "<kernel>:1:10: fatal error: 'missing.h' file not found\r\n" +
+				"#include \"missing.h\"\r\n" +
+				"         ^\r\n",
+			want: []Diagnostic{
+				{File: "<kernel>", Line: 1, Column: 10, Severity: SeverityError, Message: "'missing.h' file not found"},
+			},
+		},
+		{
+			name: "NVIDIA EDG style",
+			log: "<kernel>(3): error: identifier \"x\" is undefined\n" +
+				"\n" +
+				"<kernel>(7): warning: variable \"tmp\" was declared but never referenced\n" +
+				"\n" +
+				"1 error detected in the compilation of \"/tmp/tmpxft_00001a2b_00000000-6_kernel.cpp1.ii\".\n",
+			want: []Diagnostic{
+				{File: "<kernel>", Line: 3, Severity: SeverityError, Message: "identifier \"x\" is undefined"},
+				{File: "<kernel>", Line: 7, Severity: SeverityWarning, Message: "variable \"tmp\" was declared but never referenced"},
+			},
+		},
+		{
+			name: "AMD APP SDK",
+			log: "\"/tmp/OCL12345T5.cl\", line 3: error: identifier \"x\" is undefined\n" +
+				"      out[i] = x;\n" +
+				"               ^\n" +
+				"\n" +
+				"\"C:\\Users\\dev\\AppData\\Local\\Temp\\OCL4242.cl\", line 9: catastrophic error #5: cannot open source file \"missing.h\"\n" +
+				"\n" +
+				"2 errors detected in the compilation of \"/tmp/OCL12345T5.cl\".\n" +
+				"Frontend phase failed compilation.\n",
+			want: []Diagnostic{
+				{File: "/tmp/OCL12345T5.cl", Line: 3, Severity: SeverityError, Message: "identifier \"x\" is undefined"},
+				{File: "C:\\Users\\dev\\AppData\\Local\\Temp\\OCL4242.cl", Line: 9, Severity: SeverityError, Message: "cannot open source file \"missing.h\""},
+			},
+		},
+		{
+			name: "Windows path and missing column",
+			log:  "C:\\kernels\\blur.cl:12: warning: implicit conversion loses floating-point precision\n",
+			want: []Diagnostic{
+				{File: "C:\\kernels\\blur.cl", Line: 12, Severity: SeverityWarning, Message: "implicit conversion loses floating-point precision"},
+			},
+		},
+		{
+			name: "errors without a location",
+			log: "Compilation started\n" +
+				"error: unknown argument: '-cl-foo'\n" +
+				"warning: argument unused during compilation: '-cl-bar'\n" +
+				"ptxas application ptx input, line 12; error   : Unknown symbol 'bar'\n" +
+				"Compilation failed\n",
+			want: []Diagnostic{
+				{Severity: SeverityError, Message: "unknown argument: '-cl-foo'"},
+				{Severity: SeverityWarning, Message: "argument unused during compilation: '-cl-bar'"},
+				{Severity: SeverityError, Message: "ptxas application ptx input, line 12: Unknown symbol 'bar'"},
+			},
+		},
+		{
+			name: "echoed source is not a diagnostic",
+			log: "<kernel>:2:5: warning: unused variable 'n'\n" +
+				"    printf(\"error: %d\\n\", n);\n" +
+				"    ^\n" +
+				"error:\n" +
+				"1 warning generated.\n",
+			want: []Diagnostic{
+				{File: "<kernel>", Line: 2, Column: 5, Severity: SeverityWarning, Message: "unused variable 'n'"},
+			},
+		},
+		{
+			name: "no recognizable diagnostics",
+			log: "Compilation started\n" +
+				"Error: Failed to compile source\n" +
+				"1 error generated.\n" +
+				"Compilation failed\n",
+			want: nil,
+		},
+		{
+			name: "empty log",
+			log:  "",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseBuildLog(tt.log)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseBuildLog() =\n%#v\nwant\n%#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgramBuildErrorMessage(t *testing.T) {
+	tests := []struct {
+		name string
+		err  *ProgramBuildError
+		want string
+	}{
+		{
+			name: "no diagnostics",
+			err:  &ProgramBuildError{Err: errBuildProgramFailure},
+			want: "cl: program build failed: " + errBuildProgramFailure.Error(),
+		},
+		{
+			name: "warnings only",
+			err: &ProgramBuildError{
+				Err:         errBuildProgramFailure,
+				Diagnostics: []Diagnostic{{File: "<kernel>", Line: 2, Severity: SeverityWarning, Message: "unused"}},
+			},
+			want: "cl: program build failed: " + errBuildProgramFailure.Error(),
+		},
+		{
+			name: "several errors",
+			err: &ProgramBuildError{
+				Err: errBuildProgramFailure,
+				Diagnostics: []Diagnostic{
+					{File: "<kernel>", Line: 3, Column: 17, Severity: SeverityError, Message: "use of undeclared identifier 'x'"},
+					{File: "<kernel>", Line: 1, Column: 6, Severity: SeverityNote, Message: "declared here"},
+					{File: "<kernel>", Line: 9, Severity: SeverityError, Message: "expected ';'"},
+				},
+			},
+			want: "cl: program build failed: <kernel>:3:17: error: use of undeclared identifier 'x' (and 1 more errors)",
+		},
+		{
+			name: "error without a location",
+			err: &ProgramBuildError{
+				Err:         errBuildProgramFailure,
+				Diagnostics: []Diagnostic{{Severity: SeverityError, Message: "unknown argument: '-cl-foo'"}},
+			},
+			want: "cl: program build failed: error: unknown argument: '-cl-foo'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
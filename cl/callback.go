@@ -0,0 +1,117 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"runtime/cgo"
+	"sync/atomic"
+	"unsafe"
+)
+
+// 回调注册表
+//
+// OpenCL 的回调通过 user_data 把上下文传回给调用方，但 cgo 不允许把 Go 指针交给 C 保存。
+// 这里把 cgo.Handle 存放在 C 堆上分配的槽位中，C 侧只持有该槽位的地址，
+// 回调触发时再由槽位取回对应的 Go 值。
+
+// newCallbackHandle 为 Go 值分配回调槽位
+func newCallbackHandle(value any) unsafe.Pointer {
+	slot := C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0))))
+	*(*cgo.Handle)(slot) = cgo.NewHandle(value)
+	return slot
+}
+
+// loadCallbackHandle 取回槽位中保存的 Go 值
+func loadCallbackHandle(slot unsafe.Pointer) any {
+	return (*(*cgo.Handle)(slot)).Value()
+}
+
+// freeCallbackHandle 删除句柄并释放槽位，之后槽位不可再使用
+func freeCallbackHandle(slot unsafe.Pointer) {
+	(*(*cgo.Handle)(slot)).Delete()
+	C.free(slot)
+}
+
+// oneShotSlot 运行时可能调用也可能不调用的回调所用的槽位
+//
+// 例如 clBuildProgram 返回错误时，规范不保证回调是否还会被调用。调用方与回调各持有一个引用，
+// 先取走句柄的一方负责处理结果，另一方取到 0 时什么也不做；双方都释放引用后才回收槽位，
+// 因此迟到的回调不会访问已释放的内存。若运行时始终不调用回调，只会遗留槽位本身的十几个字节，
+// 句柄及其引用的 Go 值已由调用方删除。
+type oneShotSlot struct {
+	handle uintptr // cgo.Handle，被取走后置 0
+	refs   int32
+}
+
+// newOneShotHandle 为 Go 值分配一次性回调槽位，调用方和回调各持有一个引用
+func newOneShotHandle(value any) unsafe.Pointer {
+	slot := (*oneShotSlot)(C.malloc(C.size_t(unsafe.Sizeof(oneShotSlot{}))))
+	slot.handle = uintptr(cgo.NewHandle(value))
+	slot.refs = 2
+	return unsafe.Pointer(slot)
+}
+
+// takeOneShotHandle 取走槽位中的 Go 值，已被另一方取走时返回 nil
+func takeOneShotHandle(p unsafe.Pointer) any {
+	h := cgo.Handle(atomic.SwapUintptr(&(*oneShotSlot)(p).handle, 0))
+	if h == 0 {
+		return nil
+	}
+	defer h.Delete()
+	return h.Value()
+}
+
+// releaseOneShotHandle 释放一个引用，最后一个引用释放时回收槽位
+func releaseOneShotHandle(p unsafe.Pointer) {
+	if atomic.AddInt32(&(*oneShotSlot)(p).refs, -1) == 0 {
+		C.free(p)
+	}
+}
+
+// eventCallback 事件回调注册信息
+type eventCallback struct {
+	fn       func(Event, Int, unsafe.Pointer)
+	userData unsafe.Pointer
+}
+
+//export goEventCallback
+func goEventCallback(event C.cl_event, status C.cl_int, userData unsafe.Pointer) {
+	cb := loadCallbackHandle(userData).(*eventCallback)
+	// 每次注册只会被 OpenCL 调用一次，调用后即可释放句柄
+	defer freeCallbackHandle(userData)
+	cb.fn(Event(event), Int(status), cb.userData)
+}
+
+//export goBuildCallback
+func goBuildCallback(program C.cl_program, userData unsafe.Pointer) {
+	value := takeOneShotHandle(userData)
+	releaseOneShotHandle(userData)
+	// BuildProgramWithCallback 已同步处理了错误时，迟到的回调什么也不做
+	if fn, ok := value.(func(Program)); ok {
+		fn(Program(program))
+	}
+}
+
+//export goContextNotify
+func goContextNotify(errInfo *C.char, privateInfo unsafe.Pointer, cb C.size_t, userData unsafe.Pointer) {
+	notify := loadCallbackHandle(userData).(ContextNotifyFunc)
+
+	var private []byte
+	if privateInfo != nil && cb > 0 {
+		private = C.GoBytes(privateInfo, C.int(cb))
+	}
+	notify(C.GoString(errInfo), private)
+}
+
+//export goContextDestructor
+func goContextDestructor(context C.cl_context, userData unsafe.Pointer) {
+	// 上下文已销毁，不会再有错误通知
+	freeCallbackHandle(userData)
+}
@@ -7,12 +7,41 @@ package cl
 #cgo linux pkg-config: OpenCL
 #include <CL/cl.h>
 #include <stdlib.h>
+
+extern void goContextNotify(char *, void *, size_t, void *);
+extern void goContextDestructor(cl_context, void *);
 */
 import "C"
 import (
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
 	"unsafe"
 )
 
+// ContextNotifyFunc 上下文错误通知回调
+// errInfo 为驱动提供的错误描述，privateInfo 为驱动相关的附加调试数据（可能为空）。
+// 回调可能在 OpenCL 运行时的任意线程中并发调用，实现需保证线程安全。
+type ContextNotifyFunc func(errInfo string, privateInfo []byte)
+
+// ContextNotifyWriter 返回把错误信息逐行写入 w 的通知回调
+func ContextNotifyWriter(w io.Writer) ContextNotifyFunc {
+	var mu sync.Mutex
+	return func(errInfo string, privateInfo []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		fmt.Fprintf(w, "[OpenCL] %s (private info: %d bytes)\n", errInfo, len(privateInfo))
+	}
+}
+
+// ContextNotifyLogger 返回把错误信息记录到 logger 的通知回调
+func ContextNotifyLogger(logger *slog.Logger) ContextNotifyFunc {
+	return func(errInfo string, privateInfo []byte) {
+		logger.Error("OpenCL context error", "info", errInfo, "privateInfo", privateInfo)
+	}
+}
+
 // CreateContext 创建OpenCL上下文
 // 参数:
 //   - platform: 平台ID
@@ -23,6 +52,21 @@ import (
 //   - Context: 创建的上下文
 //   - error: 错误信息
 func CreateContext(platform PlatformID, devices []DeviceID, properties map[UInt]interface{}) (Context, error) {
+	return CreateContextWithNotify(platform, devices, properties, nil)
+}
+
+// CreateContextWithNotify 创建OpenCL上下文，并注册错误通知回调
+// 参数:
+//   - platform: 平台ID
+//   - devices: 设备ID列表
+//   - properties: 上下文属性（可选）
+//   - notify: 错误通知回调（可选），在 OpenCL 3.0 平台上于上下文销毁后自动注销；
+//     不支持 clSetContextDestructorCallback 的平台上回调会保留到进程结束
+//
+// 返回:
+//   - Context: 创建的上下文
+//   - error: 错误信息
+func CreateContextWithNotify(platform PlatformID, devices []DeviceID, properties map[UInt]interface{}, notify ContextNotifyFunc) (Context, error) {
 	var err C.cl_int
 	var context C.cl_context
 
@@ -54,14 +98,22 @@ func CreateContext(platform PlatformID, devices []DeviceID, properties map[UInt]
 		propertiesArray = append(propertiesArray, 0) // 结束标记
 	}
 
+	// 准备错误通知回调
+	var pfnNotify *[0]byte
+	var slot unsafe.Pointer
+	if notify != nil {
+		pfnNotify = (*[0]byte)(C.goContextNotify)
+		slot = newCallbackHandle(notify)
+	}
+
 	// 创建上下文
 	if len(propertiesArray) > 0 {
 		context = C.clCreateContext(
 			&propertiesArray[0],
 			deviceCount,
 			&deviceArray[0],
-			nil, // 回调函数
-			nil, // 用户数据
+			pfnNotify,
+			slot,
 			&err,
 		)
 	} else {
@@ -69,16 +121,23 @@ func CreateContext(platform PlatformID, devices []DeviceID, properties map[UInt]
 			nil,
 			deviceCount,
 			&deviceArray[0],
-			nil, // 回调函数
-			nil, // 用户数据
+			pfnNotify,
+			slot,
 			&err,
 		)
 	}
 
 	if err != C.CL_SUCCESS {
+		if slot != nil {
+			freeCallbackHandle(slot)
+		}
 		return Context(nil), OpenCLError{Code: Int(err)}
 	}
 
+	if slot != nil {
+		registerContextNotify(Context(context), slot)
+	}
+
 	return Context(context), nil
 }
 
@@ -92,6 +151,21 @@ func CreateContext(platform PlatformID, devices []DeviceID, properties map[UInt]
 //   - Context: 创建的上下文
 //   - error: 错误信息
 func CreateContextFromType(platform PlatformID, deviceType UInt, properties map[UInt]interface{}) (Context, error) {
+	return CreateContextFromTypeWithNotify(platform, deviceType, properties, nil)
+}
+
+// CreateContextFromTypeWithNotify 根据设备类型创建上下文，并注册错误通知回调
+// 参数:
+//   - platform: 平台ID
+//   - deviceType: 设备类型（如DeviceTypeGPU）
+//   - properties: 上下文属性（可选）
+//   - notify: 错误通知回调（可选），在 OpenCL 3.0 平台上于上下文销毁后自动注销；
+//     不支持 clSetContextDestructorCallback 的平台上回调会保留到进程结束
+//
+// 返回:
+//   - Context: 创建的上下文
+//   - error: 错误信息
+func CreateContextFromTypeWithNotify(platform PlatformID, deviceType UInt, properties map[UInt]interface{}, notify ContextNotifyFunc) (Context, error) {
 	var err C.cl_int
 	var context C.cl_context
 
@@ -112,29 +186,44 @@ func CreateContextFromType(platform PlatformID, deviceType UInt, properties map[
 		propertiesArray = append(propertiesArray, 0) // 结束标记
 	}
 
+	// 准备错误通知回调
+	var pfnNotify *[0]byte
+	var slot unsafe.Pointer
+	if notify != nil {
+		pfnNotify = (*[0]byte)(C.goContextNotify)
+		slot = newCallbackHandle(notify)
+	}
+
 	// 创建上下文
 	if len(propertiesArray) > 0 {
 		context = C.clCreateContextFromType(
 			&propertiesArray[0],
 			C.cl_device_type(deviceType),
-			nil, // 回调函数
-			nil, // 用户数据
+			pfnNotify,
+			slot,
 			&err,
 		)
 	} else {
 		context = C.clCreateContextFromType(
 			nil,
 			C.cl_device_type(deviceType),
-			nil, // 回调函数
-			nil, // 用户数据
+			pfnNotify,
+			slot,
 			&err,
 		)
 	}
 
 	if err != C.CL_SUCCESS {
+		if slot != nil {
+			freeCallbackHandle(slot)
+		}
 		return Context(nil), OpenCLError{Code: Int(err)}
 	}
 
+	if slot != nil {
+		registerContextNotify(Context(context), slot)
+	}
+
 	return Context(context), nil
 }
 
@@ -152,6 +241,18 @@ func ReleaseContext(context Context) error {
 	return nil
 }
 
+// registerContextNotify 安排在上下文销毁时释放通知句柄
+// 只有析构回调能确定运行时不再调用通知：队列、缓冲区、程序等对象也持有上下文的隐式引用，
+// 无法从 ReleaseContext 或引用计数推断上下文何时销毁。平台不支持析构回调（OpenCL 3.0 之前）时
+// 注册失败，句柄保留到进程结束。
+func registerContextNotify(context Context, slot unsafe.Pointer) {
+	C.clSetContextDestructorCallback(
+		C.cl_context(context),
+		(*[0]byte)(C.goContextDestructor),
+		slot,
+	)
+}
+
 // RetainContext 增加上下文的引用计数
 // 参数:
 //   - context: 上下文
@@ -246,3 +347,20 @@ func GetContextDevices(context Context) ([]DeviceID, error) {
 
 	return devices, nil
 }
+
+// GetContextReferenceCount 获取上下文引用计数
+// 参数:
+//   - context: 上下文
+//
+// 返回:
+//   - UInt: 引用计数
+//   - error: 错误信息
+func GetContextReferenceCount(context Context) (UInt, error) {
+	info, err := GetContextInfo(context, C.CL_CONTEXT_REFERENCE_COUNT, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	refCount := *(*C.cl_uint)(unsafe.Pointer(&info[0]))
+	return UInt(refCount), nil
+}
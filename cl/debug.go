@@ -72,8 +72,12 @@ func ValidateProgram(program Program, device DeviceID) error {
 		return wrapErr("GetDetailedBuildInfo", err)
 	}
 	if buildInfo.HasBuildErrors() {
-		return fmt.Errorf("program build failed: %s\nBuild log:\n%s",
-			BuildStatusString(buildInfo.Status), buildInfo.Log)
+		return &ProgramBuildError{
+			Err:         errBuildProgramFailure,
+			Device:      device,
+			Log:         buildInfo.Log,
+			Diagnostics: ParseBuildLog(buildInfo.Log),
+		}
 	}
 	if buildInfo.IsBuilding() {
 		return fmt.Errorf("program is still building")
@@ -11,7 +11,8 @@ package cl
 import "C"
 
 import (
-	"fmt"
+	"errors"
+	"strings"
 	"unsafe"
 )
 
@@ -62,6 +63,33 @@ func GetDeviceInfo(device DeviceID, paramName UInt) (string, error) {
 	return string(buffer[:size-1]), nil // 去掉末尾的null字符
 }
 
+// getDeviceInfoBytes 获取设备信息的原始字节，用于数组等非标量信息
+func getDeviceInfoBytes(device DeviceID, paramName UInt) ([]byte, error) {
+	var size C.size_t
+	errCode := Int(C.clGetDeviceInfo(
+		C.cl_device_id(device),
+		C.cl_device_info(paramName),
+		0, nil, &size))
+	if errCode != Success {
+		return nil, OpenCLError{Code: errCode}
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	buffer := make([]byte, size)
+	errCode = Int(C.clGetDeviceInfo(
+		C.cl_device_id(device),
+		C.cl_device_info(paramName),
+		size, unsafe.Pointer(&buffer[0]), nil))
+	if errCode != Success {
+		return nil, OpenCLError{Code: errCode}
+	}
+
+	return buffer, nil
+}
+
 // GetDeviceInfoUInt 获取设备UInt类型信息
 func GetDeviceInfoUInt(device DeviceID, paramName UInt) (UInt, error) {
 	var value UInt
@@ -104,46 +132,319 @@ func GetDeviceInfoULong(device DeviceID, paramName UInt) (uint64, error) {
 	return uint64(value), nil
 }
 
+// GetDeviceInfoSlice 获取数组类型的设备信息，例如
+//
+//	sizes, err := cl.GetDeviceInfoSlice[cl.Size](device, cl.DeviceMaxWorkItemSizes)
+//
+// T 的大小需与参数对应的 C 元素类型一致，否则结果无意义。
+func GetDeviceInfoSlice[T InfoElement](device DeviceID, paramName UInt) ([]T, error) {
+	info, err := getDeviceInfoBytes(device, paramName)
+	if err != nil {
+		return nil, err
+	}
+	return bytesToSlice[T](info), nil
+}
+
+// GetDeviceInfoNameVersions 获取 cl_name_version 数组类型的设备信息（需要 OpenCL 3.0），
+// 例如 DeviceExtensionsWithVersion、DeviceOpenCLCAllVersions、DeviceILsWithVersion
+func GetDeviceInfoNameVersions(device DeviceID, paramName UInt) ([]NameVersion, error) {
+	if err := requireDeviceVersion(device, 3, 0, "cl_name_version queries"); err != nil {
+		return nil, err
+	}
+	info, err := getDeviceInfoBytes(device, paramName)
+	if err != nil {
+		return nil, err
+	}
+	return decodeNameVersions(info), nil
+}
+
+// GetDeviceMaxWorkItemSizes 获取设备每个维度上工作组的最大工作项数
+func GetDeviceMaxWorkItemSizes(device DeviceID) ([]Size, error) {
+	return GetDeviceInfoSlice[Size](device, DeviceMaxWorkItemSizes)
+}
+
+// bytesToSlice 把信息查询返回的原始字节复制为 []T，末尾不足一个元素的字节被忽略
+func bytesToSlice[T InfoElement](info []byte) []T {
+	var zero T
+	count := len(info) / int(unsafe.Sizeof(zero))
+	if count == 0 {
+		return nil
+	}
+	result := make([]T, count)
+	copy(unsafe.Slice((*byte)(unsafe.Pointer(&result[0])), count*int(unsafe.Sizeof(zero))), info)
+	return result
+}
+
+// decodeNameVersions 把 cl_name_version 数组的原始字节解码为 []NameVersion
+func decodeNameVersions(info []byte) []NameVersion {
+	count := len(info) / int(unsafe.Sizeof(C.cl_name_version{}))
+	if count == 0 {
+		return nil
+	}
+	values := unsafe.Slice((*C.cl_name_version)(unsafe.Pointer(&info[0])), count)
+
+	result := make([]NameVersion, count)
+	for i := range values {
+		result[i] = NameVersion{
+			Name:    C.GoString(&values[i].name[0]),
+			Version: UInt(values[i].version),
+		}
+	}
+	return result
+}
+
 // GetDeviceDetails 获取设备完整信息
+// 基本信息查询失败时返回错误；其余查询失败时只记录在 DeviceInfo.Absent 中。
+// 版本字符串无法解析时保留原始 Version，OpenCLVersion 为零值，错误记录在 Absent[DeviceVersion] 中。
 func GetDeviceDetails(device DeviceID) (*DeviceInfo, error) {
-	info := &DeviceInfo{ID: device}
+	info := &DeviceInfo{ID: device, Absent: make(map[UInt]error)}
 
 	var err error
 	info.Name, err = GetDeviceInfo(device, DeviceName)
 	if err != nil {
-		fmt.Println("GetDeviceInfo DeviceName err:", err)
 		return nil, err
 	}
 
 	info.Vendor, err = GetDeviceInfo(device, DeviceVendor)
 	if err != nil {
-		fmt.Println("GetDeviceInfo DeviceVendor err:", err)
 		return nil, err
 	}
 
 	info.Version, err = GetDeviceInfo(device, DeviceVersion)
 	if err != nil {
-		fmt.Println("GetDeviceInfo DeviceVersion err:", err)
 		return nil, err
 	}
 
 	info.Type, err = GetDeviceInfoULong(device, DeviceType)
 	if err != nil {
-		fmt.Println("GetDeviceInfoULong err:", err)
 		return nil, err
 	}
 
 	info.MaxMemAlloc, err = GetDeviceInfoULong(device, DeviceMaxMemAlloc)
 	if err != nil {
-		fmt.Println("GetDeviceInfoUInt err:", err)
 		return nil, err
 	}
 
 	info.MaxWorkGroup, err = GetDeviceInfoSize(device, DeviceMaxWorkGroup)
 	if err != nil {
-		fmt.Println("GetDeviceInfoSize err:", err)
 		return nil, err
 	}
 
+	r := deviceInfoReader{device: device, absent: info.Absent}
+
+	if v, err := ParseVersion(info.Version); r.check(DeviceVersion, err) {
+		info.OpenCLVersion = v
+		deviceVersions.Store(device, v)
+	}
+
+	// 版本与功能
+	var platform C.cl_platform_id
+	r.raw(DevicePlatform, unsafe.Sizeof(platform), unsafe.Pointer(&platform))
+	info.Platform = PlatformID(platform)
+	r.uint(DeviceVendorID, &info.VendorID)
+	r.string(DeviceProfile, &info.Profile)
+	r.string(DeviceDriverVersion, &info.DriverVersion)
+	r.uint(DeviceNumericVersion, &info.NumericVersion)
+	r.string(DeviceLatestConformanceVersionPassed, &info.LatestConformanceVersionPassed)
+	r.string(DeviceOpenCLCVersion, &info.OpenCLCVersion)
+	r.nameVersions(DeviceOpenCLCAllVersions, &info.OpenCLCAllVersions)
+	r.nameVersions(DeviceOpenCLCFeatures, &info.OpenCLCFeatures)
+	var extensions string
+	r.string(DeviceExtensions, &extensions)
+	info.Extensions = NewExtensionSet(extensions)
+	r.nameVersions(DeviceExtensionsWithVersion, &info.ExtensionsWithVersion)
+	r.string(DeviceILVersion, &info.ILVersion)
+	r.nameVersions(DeviceILsWithVersion, &info.ILsWithVersion)
+	r.string(DeviceBuiltInKernels, &info.BuiltInKernels)
+	r.nameVersions(DeviceBuiltInKernelsWithVersion, &info.BuiltInKernelsWithVersion)
+
+	r.bool(DeviceAvailable, &info.Available)
+	r.bool(DeviceCompilerAvailable, &info.CompilerAvailable)
+	r.bool(DeviceLinkerAvailable, &info.LinkerAvailable)
+	r.bool(DeviceEndianLittle, &info.EndianLittle)
+	r.bool(DeviceErrorCorrectionSupport, &info.ErrorCorrectionSupport)
+	r.uint(DeviceAddressBits, &info.AddressBits)
+
+	// 计算单元与工作项
+	r.uint(DeviceMaxComputeUnits, &info.MaxComputeUnits)
+	r.uint(DeviceMaxClockFrequency, &info.MaxClockFrequency)
+	r.uint(DeviceMaxWorkItemDimensions, &info.MaxWorkItemDimensions)
+	r.sizes(DeviceMaxWorkItemSizes, &info.MaxWorkItemSizes)
+	r.size(DeviceMaxParameterSize, &info.MaxParameterSize)
+	r.size(DeviceProfilingTimerResolution, &info.ProfilingTimerResolution)
+	r.size(DevicePrintfBufferSize, &info.PrintfBufferSize)
+	r.size(DevicePreferredWorkGroupSizeMultiple, &info.PreferredWorkGroupSizeMultiple)
+	r.bool(DeviceNonUniformWorkGroupSupport, &info.NonUniformWorkGroupSupport)
+	r.bool(DeviceWorkGroupCollectiveFunctionsSupport, &info.WorkGroupCollectiveFunctionsSupport)
+	r.bool(DeviceGenericAddressSpaceSupport, &info.GenericAddressSpaceSupport)
+	r.bool(DevicePipeSupport, &info.PipeSupport)
+
+	// 命令队列
+	r.ulong(DeviceQueueOnHostProperties, &info.QueueOnHostProperties)
+	r.ulong(DeviceQueueOnDeviceProperties, &info.QueueOnDeviceProperties)
+	r.ulong(DeviceDeviceEnqueueCapabilities, &info.DeviceEnqueueCapabilities)
+
+	// 存储
+	r.ulong(DeviceGlobalMemSize, &info.GlobalMemSize)
+	r.uint(DeviceGlobalMemCacheType, &info.GlobalMemCacheType)
+	r.ulong(DeviceGlobalMemCacheSize, &info.GlobalMemCacheSize)
+	r.uint(DeviceGlobalMemCachelineSize, &info.GlobalMemCachelineSize)
+	r.uint(DeviceLocalMemType, &info.LocalMemType)
+	r.ulong(DeviceLocalMemSize, &info.LocalMemSize)
+	r.ulong(DeviceMaxConstantBufferSize, &info.MaxConstantBufferSize)
+	r.uint(DeviceMaxConstantArgs, &info.MaxConstantArgs)
+	r.uint(DeviceMemBaseAddrAlign, &info.MemBaseAddrAlign)
+
+	// 图像
+	r.bool(DeviceImageSupport, &info.ImageSupport)
+	r.uint(DeviceMaxReadImageArgs, &info.MaxReadImageArgs)
+	r.uint(DeviceMaxWriteImageArgs, &info.MaxWriteImageArgs)
+	r.uint(DeviceMaxReadWriteImageArgs, &info.MaxReadWriteImageArgs)
+	r.uint(DeviceMaxSamplers, &info.MaxSamplers)
+	r.size(DeviceImage2DMaxWidth, &info.Image2DMaxWidth)
+	r.size(DeviceImage2DMaxHeight, &info.Image2DMaxHeight)
+	r.size(DeviceImage3DMaxWidth, &info.Image3DMaxWidth)
+	r.size(DeviceImage3DMaxHeight, &info.Image3DMaxHeight)
+	r.size(DeviceImage3DMaxDepth, &info.Image3DMaxDepth)
+	r.size(DeviceImageMaxBufferSize, &info.ImageMaxBufferSize)
+	r.size(DeviceImageMaxArraySize, &info.ImageMaxArraySize)
+	r.uint(DeviceImagePitchAlignment, &info.ImagePitchAlignment)
+	r.uint(DeviceImageBaseAddressAlignment, &info.ImageBaseAddressAlignment)
+
+	// 向量宽度
+	r.uint(DevicePreferredVectorWidthChar, &info.PreferredVectorWidth.Char)
+	r.uint(DevicePreferredVectorWidthShort, &info.PreferredVectorWidth.Short)
+	r.uint(DevicePreferredVectorWidthInt, &info.PreferredVectorWidth.Int)
+	r.uint(DevicePreferredVectorWidthLong, &info.PreferredVectorWidth.Long)
+	r.uint(DevicePreferredVectorWidthFloat, &info.PreferredVectorWidth.Float)
+	r.uint(DevicePreferredVectorWidthDouble, &info.PreferredVectorWidth.Double)
+	r.uint(DevicePreferredVectorWidthHalf, &info.PreferredVectorWidth.Half)
+	r.uint(DeviceNativeVectorWidthChar, &info.NativeVectorWidth.Char)
+	r.uint(DeviceNativeVectorWidthShort, &info.NativeVectorWidth.Short)
+	r.uint(DeviceNativeVectorWidthInt, &info.NativeVectorWidth.Int)
+	r.uint(DeviceNativeVectorWidthLong, &info.NativeVectorWidth.Long)
+	r.uint(DeviceNativeVectorWidthFloat, &info.NativeVectorWidth.Float)
+	r.uint(DeviceNativeVectorWidthDouble, &info.NativeVectorWidth.Double)
+	r.uint(DeviceNativeVectorWidthHalf, &info.NativeVectorWidth.Half)
+
+	// 浮点能力
+	r.ulong(DeviceHalfFPConfig, &info.HalfFPConfig)
+	r.ulong(DeviceSingleFPConfig, &info.SingleFPConfig)
+	r.ulong(DeviceDoubleFPConfig, &info.DoubleFPConfig)
+
+	// SVM、原子操作与子组
+	r.ulong(DeviceSVMCapabilities, &info.SVMCapabilities)
+	r.ulong(DeviceAtomicMemoryCapabilities, &info.AtomicMemoryCapabilities)
+	r.ulong(DeviceAtomicFenceCapabilities, &info.AtomicFenceCapabilities)
+	r.uint(DeviceMaxNumSubGroups, &info.MaxNumSubGroups)
+	r.bool(DeviceSubGroupIndependentForwardProgress, &info.SubGroupIndependentForwardProgress)
+
+	// 设备划分
+	var parent C.cl_device_id
+	r.raw(DeviceParentDevice, unsafe.Sizeof(parent), unsafe.Pointer(&parent))
+	info.ParentDevice = DeviceID(parent)
+	r.uint(DevicePartitionMaxSubDevices, &info.PartitionMaxSubDevices)
+
 	return info, nil
 }
+
+// deviceInfoReader 依次读取可选的设备信息，失败的参数记录到 absent 中
+type deviceInfoReader struct {
+	device DeviceID
+	absent map[UInt]error
+}
+
+func (r *deviceInfoReader) check(paramName UInt, err error) bool {
+	if err != nil {
+		r.absent[paramName] = err
+		return false
+	}
+	return true
+}
+
+func (r *deviceInfoReader) raw(paramName UInt, size uintptr, value unsafe.Pointer) {
+	errCode := Int(C.clGetDeviceInfo(
+		C.cl_device_id(r.device),
+		C.cl_device_info(paramName),
+		C.size_t(size), value, nil))
+	if errCode != Success {
+		r.absent[paramName] = OpenCLError{Code: errCode}
+	}
+}
+
+func (r *deviceInfoReader) string(paramName UInt, dst *string) {
+	if v, err := GetDeviceInfo(r.device, paramName); r.check(paramName, err) {
+		*dst = v
+	}
+}
+
+func (r *deviceInfoReader) uint(paramName UInt, dst *UInt) {
+	if v, err := GetDeviceInfoUInt(r.device, paramName); r.check(paramName, err) {
+		*dst = v
+	}
+}
+
+func (r *deviceInfoReader) bool(paramName UInt, dst *bool) {
+	if v, err := GetDeviceInfoUInt(r.device, paramName); r.check(paramName, err) {
+		*dst = v != C.CL_FALSE
+	}
+}
+
+func (r *deviceInfoReader) size(paramName UInt, dst *Size) {
+	if v, err := GetDeviceInfoSize(r.device, paramName); r.check(paramName, err) {
+		*dst = v
+	}
+}
+
+func (r *deviceInfoReader) ulong(paramName UInt, dst *uint64) {
+	if v, err := GetDeviceInfoULong(r.device, paramName); r.check(paramName, err) {
+		*dst = v
+	}
+}
+
+func (r *deviceInfoReader) sizes(paramName UInt, dst *[]Size) {
+	if v, err := GetDeviceInfoSlice[Size](r.device, paramName); r.check(paramName, err) {
+		*dst = v
+	}
+}
+
+func (r *deviceInfoReader) nameVersions(paramName UInt, dst *[]NameVersion) {
+	if v, err := GetDeviceInfoNameVersions(r.device, paramName); r.check(paramName, err) {
+		*dst = v
+	}
+}
+
+// GetDeviceILs 获取设备支持的中间语言列表，例如 ["SPIR-V_1.2"]
+// 设备不支持 IL（OpenCL 2.1 之前的设备）时返回空列表。
+func GetDeviceILs(device DeviceID) ([]string, error) {
+	version, err := GetDeviceInfo(device, DeviceILVersion)
+	if err != nil {
+		var clErr OpenCLError
+		if errors.As(err, &clErr) && clErr.Code == C.CL_INVALID_VALUE {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return strings.Fields(version), nil
+}
+
+// GetDeviceILsWithVersion 获取设备支持的中间语言及其版本（需要 OpenCL 3.0）
+func GetDeviceILsWithVersion(device DeviceID) ([]NameVersion, error) {
+	return GetDeviceInfoNameVersions(device, DeviceILsWithVersion)
+}
+
+// DeviceSupportsIL 判断设备是否支持指定名称的中间语言，例如 "SPIR-V"
+// 不支持时调用方可回退到 CreateProgramWithSource。
+func DeviceSupportsIL(device DeviceID, name string) (bool, error) {
+	ils, err := GetDeviceILs(device)
+	if err != nil {
+		return false, err
+	}
+	for _, il := range ils {
+		// IL_VERSION 中的条目形如 "SPIR-V_1.2"
+		if il == name || strings.HasPrefix(il, name+"_") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,210 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"fmt"
+	"unsafe"
+)
+
+// DevicePartition 设备划分方式，由 PartitionEqually、PartitionByCounts 或 PartitionByAffinityDomain 创建
+type DevicePartition struct {
+	Type           UInt   // DevicePartitionEqually、DevicePartitionByCounts 或 DevicePartitionByAffinityDomain
+	ComputeUnits   UInt   // 按数量均分时每个子设备的计算单元数
+	Counts         []UInt // 按数量划分时各子设备的计算单元数
+	AffinityDomain uint64 // 按亲和域划分时的域，如 AffinityDomainNUMA
+}
+
+// PartitionEqually 把设备均分为若干个子设备，每个子设备包含 computeUnits 个计算单元
+func PartitionEqually(computeUnits UInt) DevicePartition {
+	return DevicePartition{Type: DevicePartitionEqually, ComputeUnits: computeUnits}
+}
+
+// PartitionByCounts 按给定的计算单元数依次划分子设备
+func PartitionByCounts(counts ...UInt) DevicePartition {
+	return DevicePartition{Type: DevicePartitionByCounts, Counts: counts}
+}
+
+// PartitionByAffinityDomain 按缓存或 NUMA 亲和域划分子设备
+// domain 为 AffinityDomainNUMA、AffinityDomainL1Cache 等，AffinityDomainNextPartitionable 表示下一个可划分的层级。
+func PartitionByAffinityDomain(domain uint64) DevicePartition {
+	return DevicePartition{Type: DevicePartitionByAffinityDomain, AffinityDomain: domain}
+}
+
+// String 返回划分方式的可读描述
+func (p DevicePartition) String() string {
+	switch p.Type {
+	case DevicePartitionEqually:
+		return fmt.Sprintf("Equally(%d)", p.ComputeUnits)
+	case DevicePartitionByCounts:
+		return fmt.Sprintf("ByCounts%v", p.Counts)
+	case DevicePartitionByAffinityDomain:
+		return fmt.Sprintf("ByAffinityDomain(0x%x)", p.AffinityDomain)
+	default:
+		return fmt.Sprintf("Unknown partition type (code: 0x%x)", p.Type)
+	}
+}
+
+// properties 转换为以 0 结尾的 cl_device_partition_property 列表
+func (p DevicePartition) properties() ([]C.cl_device_partition_property, error) {
+	switch p.Type {
+	case DevicePartitionEqually:
+		return []C.cl_device_partition_property{
+			C.CL_DEVICE_PARTITION_EQUALLY, C.cl_device_partition_property(p.ComputeUnits), 0,
+		}, nil
+	case DevicePartitionByCounts:
+		if len(p.Counts) == 0 {
+			return nil, OpenCLError{Code: Int(C.CL_INVALID_DEVICE_PARTITION_COUNT)}
+		}
+		props := make([]C.cl_device_partition_property, 0, len(p.Counts)+3)
+		props = append(props, C.CL_DEVICE_PARTITION_BY_COUNTS)
+		for _, count := range p.Counts {
+			props = append(props, C.cl_device_partition_property(count))
+		}
+		return append(props, C.CL_DEVICE_PARTITION_BY_COUNTS_LIST_END, 0), nil
+	case DevicePartitionByAffinityDomain:
+		return []C.cl_device_partition_property{
+			C.CL_DEVICE_PARTITION_BY_AFFINITY_DOMAIN, C.cl_device_partition_property(p.AffinityDomain), 0,
+		}, nil
+	default:
+		return nil, OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+}
+
+// CreateSubDevices 按 partition 把设备划分为子设备
+// 参数:
+//   - device: 父设备，可以是根设备或子设备
+//   - partition: 划分方式
+//
+// 返回:
+//   - []DeviceID: 创建的子设备，可像普通设备一样用于创建上下文和命令队列，使用完毕后需调用 ReleaseDevice
+//   - error: 错误信息
+func CreateSubDevices(device DeviceID, partition DevicePartition) ([]DeviceID, error) {
+	props, err := partition.properties()
+	if err != nil {
+		return nil, err
+	}
+	if err := requireDeviceVersion(device, 1, 2, "clCreateSubDevices"); err != nil {
+		return nil, err
+	}
+
+	var num C.cl_uint
+	if errCode := C.clCreateSubDevices(C.cl_device_id(device), &props[0], 0, nil, &num); errCode != C.CL_SUCCESS {
+		return nil, OpenCLError{Code: Int(errCode)}
+	}
+	if num == 0 {
+		return nil, nil
+	}
+
+	ids := make([]C.cl_device_id, num)
+	if errCode := C.clCreateSubDevices(C.cl_device_id(device), &props[0], num, &ids[0], &num); errCode != C.CL_SUCCESS {
+		return nil, OpenCLError{Code: Int(errCode)}
+	}
+
+	result := make([]DeviceID, num)
+	for i := range result {
+		result[i] = DeviceID(ids[i])
+	}
+	return result, nil
+}
+
+// RetainDevice 增加子设备的引用计数，对根设备无效果
+func RetainDevice(device DeviceID) error {
+	err := C.clRetainDevice(C.cl_device_id(device))
+	if err != C.CL_SUCCESS {
+		return OpenCLError{Code: Int(err)}
+	}
+	return nil
+}
+
+// ReleaseDevice 释放子设备，对根设备无效果
+func ReleaseDevice(device DeviceID) error {
+	err := C.clReleaseDevice(C.cl_device_id(device))
+	if err != C.CL_SUCCESS {
+		return OpenCLError{Code: Int(err)}
+	}
+	// 子设备句柄释放后可能被复用
+	deviceVersions.Delete(device)
+	deviceSVMCapabilities.Delete(device)
+	return nil
+}
+
+// GetDeviceParentDevice 获取子设备的父设备，根设备返回 nil
+func GetDeviceParentDevice(device DeviceID) (DeviceID, error) {
+	var parent C.cl_device_id
+	errCode := Int(C.clGetDeviceInfo(
+		C.cl_device_id(device),
+		C.CL_DEVICE_PARENT_DEVICE,
+		C.size_t(unsafe.Sizeof(parent)),
+		unsafe.Pointer(&parent), nil))
+	if errCode != Success {
+		return DeviceID(nil), OpenCLError{Code: errCode}
+	}
+	return DeviceID(parent), nil
+}
+
+// GetDevicePartitionMaxSubDevices 获取设备最多可划分的子设备数
+func GetDevicePartitionMaxSubDevices(device DeviceID) (UInt, error) {
+	return GetDeviceInfoUInt(device, DevicePartitionMaxSubDevices)
+}
+
+// GetDevicePartitionProperties 获取设备支持的划分方式，不支持划分时返回空列表
+func GetDevicePartitionProperties(device DeviceID) ([]UInt, error) {
+	props, err := getDevicePartitionProperties(device, DevicePartitionProperties)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []UInt
+	for _, p := range props {
+		if p != 0 {
+			result = append(result, UInt(p))
+		}
+	}
+	return result, nil
+}
+
+// GetDevicePartitionAffinityDomain 获取设备支持的亲和域，结果为 AffinityDomain* 标志的组合
+func GetDevicePartitionAffinityDomain(device DeviceID) (uint64, error) {
+	return GetDeviceInfoULong(device, DevicePartitionAffinityDomain)
+}
+
+// GetDevicePartitionType 获取创建该子设备时使用的划分方式，根设备返回 ok 为 false
+func GetDevicePartitionType(device DeviceID) (partition DevicePartition, ok bool, err error) {
+	props, err := getDevicePartitionProperties(device, DevicePartitionType)
+	if err != nil || len(props) == 0 || props[0] == 0 {
+		return DevicePartition{}, false, err
+	}
+
+	partition.Type = UInt(props[0])
+	switch partition.Type {
+	case DevicePartitionEqually:
+		if len(props) > 1 {
+			partition.ComputeUnits = UInt(props[1])
+		}
+	case DevicePartitionByCounts:
+		for _, count := range props[1:] {
+			if count == C.CL_DEVICE_PARTITION_BY_COUNTS_LIST_END {
+				break
+			}
+			partition.Counts = append(partition.Counts, UInt(count))
+		}
+	case DevicePartitionByAffinityDomain:
+		if len(props) > 1 {
+			partition.AffinityDomain = uint64(props[1])
+		}
+	}
+	return partition, true, nil
+}
+
+// getDevicePartitionProperties 读取 cl_device_partition_property 数组类型的设备信息
+func getDevicePartitionProperties(device DeviceID, paramName UInt) ([]C.cl_device_partition_property, error) {
+	return GetDeviceInfoSlice[C.cl_device_partition_property](device, paramName)
+}
@@ -7,12 +7,21 @@ package cl
 #cgo linux pkg-config: OpenCL
 #include <CL/cl.h>
 #include <stdlib.h>
+
+extern void goEventCallback(cl_event, cl_int, void *);
 */
 import "C"
 import (
+	"errors"
+	"fmt"
+	"time"
 	"unsafe"
 )
 
+// ErrProfilingNotAvailable 事件没有性能分析数据
+// 命令队列未启用 QueueProfilingEnable、命令尚未完成或事件为用户事件时返回。
+var ErrProfilingNotAvailable = errors.New("cl: event profiling information not available")
+
 // CreateUserEvent 创建用户事件
 func CreateUserEvent(context Context) (Event, error) {
 	var err C.cl_int
@@ -139,6 +148,16 @@ func GetEventCommandExecStatus(event Event) (Int, error) {
 	return Int(execStatus), nil
 }
 
+// CommandStatusError 把命令执行状态转换为错误
+// 状态为 CommandQueued、CommandSubmitted、CommandRunning 或 CommandComplete 时返回 nil；
+// 命令异常终止时状态为负的错误码，返回对应的 OpenCLError。
+func CommandStatusError(status Int) error {
+	if status < 0 {
+		return OpenCLError{Code: status}
+	}
+	return nil
+}
+
 // GetEventContext 获取事件关联的上下文
 func GetEventContext(event Event) (Context, error) {
 	var contextPtr C.cl_context
@@ -163,21 +182,127 @@ func GetEventReferenceCount(event Event) (UInt, error) {
 }
 
 // SetEventCallback 设置事件回调函数
+// 参数:
+//   - event: 事件
+//   - commandExecCallbackType: 触发回调的执行状态（CommandSubmitted、CommandRunning 或 CommandComplete）
+//   - callback: 回调函数，在 OpenCL 运行时的线程中调用，不应长时间阻塞
+//   - userData: 原样传回给回调函数的用户数据，仅在 Go 侧保存
+//
+// 每次注册的回调只会被调用一次；若命令异常终止，status 为负的错误码，可用 CommandStatusError 转换为错误。
+// 需要关注多个状态时，为每个状态分别注册即可。
 func SetEventCallback(event Event, commandExecCallbackType UInt, callback func(Event, Int, unsafe.Pointer), userData unsafe.Pointer) error {
-	// 注意：这里需要将Go的回调函数转换为C函数指针
-	// 由于Go和C之间的回调函数转换比较复杂，这里提供一个简化版本
-	// 实际使用时可能需要更复杂的C包装函数
+	if callback == nil {
+		return OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+
+	slot := newCallbackHandle(&eventCallback{fn: callback, userData: userData})
 
 	err := C.clSetEventCallback(
 		C.cl_event(event),
 		C.cl_int(commandExecCallbackType),
-		nil, // 这里需要C函数指针，实际实现时需要C包装函数
-		userData,
+		(*[0]byte)(C.goEventCallback),
+		slot,
 	)
 
 	if err != C.CL_SUCCESS {
+		// 注册失败时回调不会被调用，需要在这里释放句柄
+		freeCallbackHandle(slot)
 		return OpenCLError{Code: Int(err)}
 	}
 
 	return nil
 }
+
+// EventProfilingInfo 事件性能分析时间戳，单位为设备时钟纳秒
+type EventProfilingInfo struct {
+	Queued    uint64 // 命令进入队列
+	Submitted uint64 // 命令提交到设备
+	Start     uint64 // 命令开始执行
+	End       uint64 // 命令执行结束
+	Complete  uint64 // 命令及其子命令全部完成（OpenCL 2.0 之前的平台与 End 相同）
+}
+
+// QueueLatency 命令从入队到开始执行的等待时间
+func (p *EventProfilingInfo) QueueLatency() time.Duration {
+	return profilingDuration(p.Queued, p.Start)
+}
+
+// SubmitLatency 命令从提交到开始执行的等待时间
+func (p *EventProfilingInfo) SubmitLatency() time.Duration {
+	return profilingDuration(p.Submitted, p.Start)
+}
+
+// ExecutionTime 命令在设备上的执行时间
+func (p *EventProfilingInfo) ExecutionTime() time.Duration {
+	return profilingDuration(p.Start, p.End)
+}
+
+// TotalTime 命令从入队到完成的总时间
+func (p *EventProfilingInfo) TotalTime() time.Duration {
+	return profilingDuration(p.Queued, p.Complete)
+}
+
+// profilingDuration 返回两个时间戳之间的时长
+// 驱动返回的时间戳乱序（to 早于 from）时返回 0，而不是让无符号减法回绕成几百年。
+func profilingDuration(from, to uint64) time.Duration {
+	if to < from {
+		return 0
+	}
+	return time.Duration(to - from)
+}
+
+// GetEventProfilingCounter 获取单个性能分析时间戳
+func GetEventProfilingCounter(event Event, paramName UInt) (uint64, error) {
+	var value C.cl_ulong
+
+	err := C.clGetEventProfilingInfo(
+		C.cl_event(event),
+		C.cl_profiling_info(paramName),
+		C.size_t(unsafe.Sizeof(value)),
+		unsafe.Pointer(&value),
+		nil,
+	)
+
+	if err == C.CL_PROFILING_INFO_NOT_AVAILABLE {
+		return 0, fmt.Errorf("%w: %w", ErrProfilingNotAvailable, OpenCLError{Code: Int(err)})
+	}
+	if err != C.CL_SUCCESS {
+		return 0, OpenCLError{Code: Int(err)}
+	}
+
+	return uint64(value), nil
+}
+
+// GetEventProfilingInfo 获取事件的全部性能分析时间戳
+// 队列需以 QueueProfilingEnable 创建，且命令已经完成，否则返回 ErrProfilingNotAvailable。
+func GetEventProfilingInfo(event Event) (*EventProfilingInfo, error) {
+	info := &EventProfilingInfo{}
+
+	counters := []struct {
+		name  UInt
+		value *uint64
+	}{
+		{ProfilingCommandQueued, &info.Queued},
+		{ProfilingCommandSubmit, &info.Submitted},
+		{ProfilingCommandStart, &info.Start},
+		{ProfilingCommandEnd, &info.End},
+	}
+
+	for _, c := range counters {
+		value, err := GetEventProfilingCounter(event, c.name)
+		if err != nil {
+			return nil, err
+		}
+		*c.value = value
+	}
+
+	// CL_PROFILING_COMMAND_COMPLETE 自 OpenCL 2.0 起提供
+	// 部分驱动支持该查询但返回 0，同样退回到 End
+	complete, err := GetEventProfilingCounter(event, ProfilingCommandComplete)
+	if err != nil || complete == 0 {
+		complete = info.End
+	}
+	info.Complete = complete
+
+	return info, nil
+}
@@ -7,6 +7,11 @@ package cl
 #cgo linux pkg-config: OpenCL
 #include <CL/cl.h>
 #include <stdlib.h>
+
+// cl_image_desc 中的 buffer 字段在部分头文件中位于匿名联合体内，由 C 侧赋值
+static void setImageDescBuffer(cl_image_desc *desc, cl_mem buffer) {
+	desc->buffer = buffer;
+}
 */
 import "C"
 import (
@@ -118,6 +123,7 @@ func CreateImage(context Context, flags UInt, imageFormat ImageFormat, imageDesc
 		num_mip_levels:    C.cl_uint(imageDesc.NumMipLevels),
 		num_samples:       C.cl_uint(imageDesc.NumSamples),
 	}
+	C.setImageDescBuffer(&desc, C.cl_mem(imageDesc.Buffer))
 
 	image := C.clCreateImage(
 		C.cl_context(context),
@@ -183,6 +189,117 @@ func GetSupportedImageFormats(context Context, flags UInt, imageType UInt) ([]Im
 	return result, nil
 }
 
+// getImageInfoBytes 获取图像信息的原始字节
+func getImageInfoBytes(image MemObject, paramName UInt) ([]byte, error) {
+	var paramValueSizeRet C.size_t
+
+	// 第一次调用，获取需要的大小
+	err := C.clGetImageInfo(
+		C.cl_mem(image),
+		C.cl_image_info(paramName),
+		0,
+		nil,
+		&paramValueSizeRet,
+	)
+	if err != C.CL_SUCCESS {
+		return nil, OpenCLError{Code: Int(err)}
+	}
+
+	if paramValueSizeRet == 0 {
+		return nil, nil
+	}
+
+	paramValue := make([]byte, paramValueSizeRet)
+
+	// 第二次调用，真正获取数据
+	err = C.clGetImageInfo(
+		C.cl_mem(image),
+		C.cl_image_info(paramName),
+		paramValueSizeRet,
+		unsafe.Pointer(&paramValue[0]),
+		nil,
+	)
+	if err != C.CL_SUCCESS {
+		return nil, OpenCLError{Code: Int(err)}
+	}
+
+	return paramValue, nil
+}
+
+// GetImageFormat 获取图像格式
+func GetImageFormat(image MemObject) (ImageFormat, error) {
+	info, err := getImageInfoBytes(image, C.CL_IMAGE_FORMAT)
+	if err != nil {
+		return ImageFormat{}, err
+	}
+
+	format := *(*C.cl_image_format)(unsafe.Pointer(&info[0]))
+	return ImageFormat{
+		ChannelOrder: UInt(format.image_channel_order),
+		ChannelType:  UInt(format.image_channel_data_type),
+	}, nil
+}
+
+// GetImageInfo 获取图像完整信息，返回可直接用于 CreateImage 的格式与描述符
+func GetImageInfo(image MemObject) (*ImageInfo, error) {
+	info := &ImageInfo{}
+
+	var err error
+	info.Format, err = GetImageFormat(image)
+	if err != nil {
+		return nil, err
+	}
+
+	memType, err := GetMemObjectInfo(image, C.CL_MEM_TYPE)
+	if err != nil {
+		return nil, err
+	}
+	info.Desc.ImageType = UInt(*(*C.cl_mem_object_type)(unsafe.Pointer(&memType[0])))
+
+	sizeFields := []struct {
+		name  UInt
+		value *Size
+	}{
+		{ImageElementSize, &info.ElementSize},
+		{ImageRowPitch, &info.Desc.RowPitch},
+		{ImageSlicePitch, &info.Desc.SlicePitch},
+		{ImageWidth, &info.Desc.Width},
+		{ImageHeight, &info.Desc.Height},
+		{ImageDepth, &info.Desc.Depth},
+		{ImageArraySize, &info.Desc.ArraySize},
+	}
+	for _, f := range sizeFields {
+		value, err := getImageInfoBytes(image, f.name)
+		if err != nil {
+			return nil, err
+		}
+		*f.value = Size(*(*C.size_t)(unsafe.Pointer(&value[0])))
+	}
+
+	uintFields := []struct {
+		name  UInt
+		value *UInt
+	}{
+		{ImageNumMipLevels, &info.Desc.NumMipLevels},
+		{ImageNumSamples, &info.Desc.NumSamples},
+	}
+	for _, f := range uintFields {
+		value, err := getImageInfoBytes(image, f.name)
+		if err != nil {
+			return nil, err
+		}
+		*f.value = UInt(*(*C.cl_uint)(unsafe.Pointer(&value[0])))
+	}
+
+	buffer, err := getImageInfoBytes(image, C.CL_IMAGE_BUFFER)
+	if err != nil {
+		return nil, err
+	}
+	info.Desc.Buffer = MemObject(*(*C.cl_mem)(unsafe.Pointer(&buffer[0])))
+
+	return info, nil
+}
+
 func EnqueueReadImage(queue CommandQueue, image MemObject, blocking Bool, origin [3]Size, region [3]Size, rowPitch Size, slicePitch Size, ptr unsafe.Pointer, eventWaitList []Event) (Event, error) {
 	var err C.cl_int
 	var event C.cl_event
@@ -299,6 +416,129 @@ func EnqueueCopyImage(queue CommandQueue, srcImage MemObject, dstImage MemObject
 	return Event(event), nil
 }
 
+// EnqueueCopyImageToBuffer 把图像中 srcOrigin 起、大小为 region 的区域复制到缓冲区的 dstOffset 处
+func EnqueueCopyImageToBuffer(queue CommandQueue, srcImage MemObject, dstBuffer MemObject, srcOrigin [3]Size, region [3]Size, dstOffset Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	srcOriginArray := [3]C.size_t{C.size_t(srcOrigin[0]), C.size_t(srcOrigin[1]), C.size_t(srcOrigin[2])}
+	regionArray := [3]C.size_t{C.size_t(region[0]), C.size_t(region[1]), C.size_t(region[2])}
+
+	err = C.clEnqueueCopyImageToBuffer(
+		C.cl_command_queue(queue),
+		C.cl_mem(srcImage),
+		C.cl_mem(dstBuffer),
+		&srcOriginArray[0],
+		&regionArray[0],
+		C.size_t(dstOffset),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueCopyBufferToImage 把缓冲区 srcOffset 处的数据复制到图像中 dstOrigin 起、大小为 region 的区域
+func EnqueueCopyBufferToImage(queue CommandQueue, srcBuffer MemObject, dstImage MemObject, srcOffset Size, dstOrigin [3]Size, region [3]Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	dstOriginArray := [3]C.size_t{C.size_t(dstOrigin[0]), C.size_t(dstOrigin[1]), C.size_t(dstOrigin[2])}
+	regionArray := [3]C.size_t{C.size_t(region[0]), C.size_t(region[1]), C.size_t(region[2])}
+
+	err = C.clEnqueueCopyBufferToImage(
+		C.cl_command_queue(queue),
+		C.cl_mem(srcBuffer),
+		C.cl_mem(dstImage),
+		C.size_t(srcOffset),
+		&dstOriginArray[0],
+		&regionArray[0],
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// ImageFillColor 图像填充颜色类型
+// 浮点或归一化通道格式使用 Float4，有符号整数格式使用 Int4，无符号整数格式使用 UInt4。
+type ImageFillColor interface {
+	Float4 | Int4 | UInt4
+}
+
+// EnqueueFillImage 用 fillColor 填充图像中 origin 起、大小为 region 的区域
+func EnqueueFillImage[V ImageFillColor](queue CommandQueue, image MemObject, fillColor V, origin [3]Size, region [3]Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if err := requireQueueVersion(queue, 1, 2, "clEnqueueFillImage"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	originArray := [3]C.size_t{C.size_t(origin[0]), C.size_t(origin[1]), C.size_t(origin[2])}
+	regionArray := [3]C.size_t{C.size_t(region[0]), C.size_t(region[1]), C.size_t(region[2])}
+
+	err = C.clEnqueueFillImage(
+		C.cl_command_queue(queue),
+		C.cl_mem(image),
+		unsafe.Pointer(&fillColor),
+		&originArray[0],
+		&regionArray[0],
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
 func EnqueueMapImage(queue CommandQueue, image MemObject, blocking Bool, mapFlags UInt, origin [3]Size, region [3]Size, imageRowPitch *Size, imageSlicePitch *Size, eventWaitList []Event) (unsafe.Pointer, Size, Size, Event, error) {
 	var err C.cl_int
 	var event C.cl_event
@@ -0,0 +1,494 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"unsafe"
+)
+
+// Go 图像与 OpenCL 二维图像之间的转换
+//
+// 设备端格式按以下规则选择：优先使用与 Go 图像内存布局一致的格式，设备不支持时依次尝试
+// 其他格式，并在主机端完成像素转换。设备端的颜色分量一律为非预乘 Alpha：8 位格式对应
+// image.NRGBA / image.Gray，16 位、半精度和单精度浮点格式对应 image.NRGBA64 / image.Gray16；
+// image.RGBA 等预乘图像在上传时转换为非预乘分量。转换规则与标准库 image/color 的颜色模型一致。
+
+// CreateImageFromGo 根据 Go 图像创建二维图像，并把像素数据复制到设备
+// 参数:
+//   - context: 上下文
+//   - flags: 内存标志（MemReadOnly 等），主机指针相关标志会被忽略，数据总是复制到图像中
+//   - img: 源图像，支持任意 image.Image，image.NRGBA、image.Gray 等类型可直接使用原生布局
+//
+// 返回:
+//   - MemObject: 创建的图像
+//   - ImageFormat: 实际使用的图像格式
+//   - error: 错误信息
+func CreateImageFromGo(context Context, flags UInt, img image.Image) (MemObject, ImageFormat, error) {
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return MemObject(nil), ImageFormat{}, fmt.Errorf("cl: CreateImageFromGo with empty image: %w",
+			OpenCLError{Code: Int(C.CL_INVALID_IMAGE_SIZE)})
+	}
+	flags &^= MemUseHostPtr | MemAllocHostPtr | MemCopyHostPtr
+
+	format, err := chooseImageFormat(context, flags, img)
+	if err != nil {
+		return MemObject(nil), ImageFormat{}, err
+	}
+
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// 8 位 NRGBA / 灰度图像可直接使用 Pix，按 Stride 作为行间距
+	if pix, stride, ok := nativePix(img, format); ok && len(pix) >= stride*height {
+		mem, err := CreateImage2D(context, flags|MemCopyHostPtr, format, Size(width), Size(height),
+			Size(stride), unsafe.Pointer(&pix[0]))
+		return mem, format, err
+	}
+
+	data, err := encodeGoImage(img, format)
+	if err != nil {
+		return MemObject(nil), ImageFormat{}, err
+	}
+
+	pixelSize, _ := imagePixelSize(format)
+	mem, err := CreateImage2D(context, flags|MemCopyHostPtr, format, Size(width), Size(height),
+		Size(width*pixelSize), unsafe.Pointer(&data[0]))
+	return mem, format, err
+}
+
+// ReadGoImage 读取整个二维图像，返回与图像格式最接近的 Go 图像
+// 8 位格式返回 *image.NRGBA 或 *image.Gray，其他格式返回 *image.NRGBA64 或 *image.Gray16。
+// 阻塞直到读取完成。
+func ReadGoImage(queue CommandQueue, mem MemObject) (image.Image, error) {
+	info, err := GetImageInfo(mem)
+	if err != nil {
+		return nil, err
+	}
+	if info.Desc.ImageType != MemObjectImage2D {
+		return nil, fmt.Errorf("cl: ReadGoImage requires a 2D image, got image type 0x%x: %w",
+			info.Desc.ImageType, OpenCLError{Code: Int(C.CL_INVALID_MEM_OBJECT)})
+	}
+
+	pixelSize, err := imagePixelSize(info.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := int(info.Desc.Width), int(info.Desc.Height)
+	data := make([]byte, width*height*pixelSize)
+	event, err := EnqueueReadImage(queue, mem, Bool(C.CL_TRUE), [3]Size{0, 0, 0},
+		[3]Size{Size(width), Size(height), 1}, Size(width*pixelSize), 0, unsafe.Pointer(&data[0]), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := ReleaseEvent(event); err != nil {
+		return nil, err
+	}
+
+	return decodeGoImage(data, width, height, info.Format)
+}
+
+// ReadImageRGBA 读取二维图像并转换为 *image.RGBA（预乘 Alpha）
+func ReadImageRGBA(queue CommandQueue, mem MemObject) (*image.RGBA, error) {
+	img, err := ReadGoImage(queue, mem)
+	if err != nil {
+		return nil, err
+	}
+
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, image.Point{}, draw.Src)
+	return rgba, nil
+}
+
+// ReadImageGray 读取二维图像并转换为 *image.Gray
+func ReadImageGray(queue CommandQueue, mem MemObject) (*image.Gray, error) {
+	img, err := ReadGoImage(queue, mem)
+	if err != nil {
+		return nil, err
+	}
+	if gray, ok := img.(*image.Gray); ok {
+		return gray, nil
+	}
+
+	gray := image.NewGray(img.Bounds())
+	draw.Draw(gray, gray.Bounds(), img, image.Point{}, draw.Src)
+	return gray, nil
+}
+
+// ReadImageNRGBA 读取二维图像并转换为 *image.NRGBA（非预乘 Alpha，与设备端数据一致）
+func ReadImageNRGBA(queue CommandQueue, mem MemObject) (*image.NRGBA, error) {
+	img, err := ReadGoImage(queue, mem)
+	if err != nil {
+		return nil, err
+	}
+	if nrgba, ok := img.(*image.NRGBA); ok {
+		return nrgba, nil
+	}
+
+	nrgba := image.NewNRGBA(img.Bounds())
+	draw.Draw(nrgba, nrgba.Bounds(), img, image.Point{}, draw.Src)
+	return nrgba, nil
+}
+
+// ReadImageNRGBA64 读取二维图像并转换为 *image.NRGBA64
+func ReadImageNRGBA64(queue CommandQueue, mem MemObject) (*image.NRGBA64, error) {
+	img, err := ReadGoImage(queue, mem)
+	if err != nil {
+		return nil, err
+	}
+	if nrgba, ok := img.(*image.NRGBA64); ok {
+		return nrgba, nil
+	}
+
+	nrgba := image.NewNRGBA64(img.Bounds())
+	draw.Draw(nrgba, nrgba.Bounds(), img, image.Point{}, draw.Src)
+	return nrgba, nil
+}
+
+// goImageFormatCandidates 按优先级列出 Go 图像可使用的设备格式，首个为原生布局
+func goImageFormatCandidates(img image.Image) []ImageFormat {
+	rgba8 := []ImageFormat{
+		{ChannelOrderRGBA, ChannelTypeUNormInt8},
+		{ChannelOrderBGRA, ChannelTypeUNormInt8},
+		{ChannelOrderRGBA, ChannelTypeUNormInt16},
+		{ChannelOrderRGBA, ChannelTypeFloat},
+	}
+
+	switch img.(type) {
+	case *image.Gray:
+		return append([]ImageFormat{
+			{ChannelOrderR, ChannelTypeUNormInt8},
+			{ChannelOrderLuminance, ChannelTypeUNormInt8},
+		}, rgba8...)
+	case *image.Gray16:
+		return []ImageFormat{
+			{ChannelOrderR, ChannelTypeUNormInt16},
+			{ChannelOrderLuminance, ChannelTypeUNormInt16},
+			{ChannelOrderR, ChannelTypeFloat},
+			{ChannelOrderRGBA, ChannelTypeUNormInt16},
+			{ChannelOrderRGBA, ChannelTypeFloat},
+		}
+	case *image.NRGBA64, *image.RGBA64:
+		return []ImageFormat{
+			{ChannelOrderRGBA, ChannelTypeUNormInt16},
+			{ChannelOrderRGBA, ChannelTypeFloat},
+			{ChannelOrderRGBA, ChannelTypeHalfFloat},
+			{ChannelOrderRGBA, ChannelTypeUNormInt8},
+			{ChannelOrderBGRA, ChannelTypeUNormInt8},
+		}
+	default:
+		// image.NRGBA、image.RGBA、image.YCbCr 等 8 位图像
+		return rgba8
+	}
+}
+
+// chooseImageFormat 选择设备支持的第一个候选格式
+func chooseImageFormat(context Context, flags UInt, img image.Image) (ImageFormat, error) {
+	supported, err := GetSupportedImageFormats(context, flags, MemObjectImage2D)
+	if err != nil {
+		return ImageFormat{}, err
+	}
+
+	candidates := goImageFormatCandidates(img)
+	for _, candidate := range candidates {
+		for _, format := range supported {
+			if format == candidate {
+				return candidate, nil
+			}
+		}
+	}
+
+	return ImageFormat{}, fmt.Errorf("cl: no supported image format for %T: %w",
+		img, OpenCLError{Code: Int(C.CL_IMAGE_FORMAT_NOT_SUPPORTED)})
+}
+
+// nativePix 当 Go 图像与设备格式的内存布局完全一致时返回其像素数据与行间距
+func nativePix(img image.Image, format ImageFormat) ([]byte, int, bool) {
+	if format.ChannelType != ChannelTypeUNormInt8 {
+		return nil, 0, false
+	}
+
+	switch src := img.(type) {
+	case *image.NRGBA:
+		if format.ChannelOrder == ChannelOrderRGBA {
+			return src.Pix, src.Stride, true
+		}
+	case *image.Gray:
+		if format.ChannelOrder == ChannelOrderR || format.ChannelOrder == ChannelOrderLuminance {
+			return src.Pix, src.Stride, true
+		}
+	}
+	return nil, 0, false
+}
+
+// imageChannels 返回设备通道依次对应的 RGBA 分量下标，单通道格式对应灰度值
+func imageChannels(order UInt) ([]int, error) {
+	switch order {
+	case ChannelOrderR, ChannelOrderLuminance, ChannelOrderIntensity:
+		return []int{0}, nil
+	case ChannelOrderRGBA:
+		return []int{0, 1, 2, 3}, nil
+	case ChannelOrderBGRA:
+		return []int{2, 1, 0, 3}, nil
+	default:
+		return nil, fmt.Errorf("cl: unsupported channel order 0x%x for Go image conversion: %w",
+			order, OpenCLError{Code: Int(C.CL_IMAGE_FORMAT_NOT_SUPPORTED)})
+	}
+}
+
+// imageChannelSize 返回单个通道的字节数
+func imageChannelSize(channelType UInt) (int, error) {
+	switch channelType {
+	case ChannelTypeUNormInt8:
+		return 1, nil
+	case ChannelTypeUNormInt16, ChannelTypeHalfFloat:
+		return 2, nil
+	case ChannelTypeFloat:
+		return 4, nil
+	default:
+		return 0, fmt.Errorf("cl: unsupported channel type 0x%x for Go image conversion: %w",
+			channelType, OpenCLError{Code: Int(C.CL_IMAGE_FORMAT_NOT_SUPPORTED)})
+	}
+}
+
+// imagePixelSize 返回可转换格式的像素字节数
+func imagePixelSize(format ImageFormat) (int, error) {
+	channels, err := imageChannels(format.ChannelOrder)
+	if err != nil {
+		return 0, err
+	}
+	channelSize, err := imageChannelSize(format.ChannelType)
+	if err != nil {
+		return 0, err
+	}
+	return len(channels) * channelSize, nil
+}
+
+// encodeGoImage 把 Go 图像转换为 format 布局的紧密排列像素数据
+func encodeGoImage(img image.Image, format ImageFormat) ([]byte, error) {
+	channels, err := imageChannels(format.ChannelOrder)
+	if err != nil {
+		return nil, err
+	}
+	channelSize, err := imageChannelSize(format.ChannelType)
+	if err != nil {
+		return nil, err
+	}
+
+	// 得到 16 位的非预乘 RGBA 或灰度分量，灰度按目标精度选择颜色模型
+	eightBit := format.ChannelType == ChannelTypeUNormInt8
+	gray := len(channels) == 1
+	pixelAt := func(x, y int) [4]uint16 {
+		c := img.At(x, y)
+		switch {
+		case gray && eightBit:
+			v := uint16(color.GrayModel.Convert(c).(color.Gray).Y)
+			return [4]uint16{v * 0x101}
+		case gray:
+			return [4]uint16{color.Gray16Model.Convert(c).(color.Gray16).Y}
+		default:
+			return straightRGBA(c)
+		}
+	}
+
+	bounds := img.Bounds()
+	pixelSize := len(channels) * channelSize
+	data := make([]byte, bounds.Dx()*bounds.Dy()*pixelSize)
+
+	offset := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := pixelAt(x, y)
+			for _, c := range channels {
+				putImageChannel(data[offset:], format.ChannelType, pixel[c])
+				offset += channelSize
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// decodeGoImage 把 format 布局的紧密排列像素数据转换为 Go 图像
+func decodeGoImage(data []byte, width, height int, format ImageFormat) (image.Image, error) {
+	channels, err := imageChannels(format.ChannelOrder)
+	if err != nil {
+		return nil, err
+	}
+	channelSize, err := imageChannelSize(format.ChannelType)
+	if err != nil {
+		return nil, err
+	}
+
+	rect := image.Rect(0, 0, width, height)
+	eightBit := format.ChannelType == ChannelTypeUNormInt8
+
+	// 与 Go 图像布局一致时直接使用读回的数据
+	switch {
+	case eightBit && len(channels) == 1:
+		return &image.Gray{Pix: data, Stride: width, Rect: rect}, nil
+	case eightBit && format.ChannelOrder == ChannelOrderRGBA:
+		return &image.NRGBA{Pix: data, Stride: width * 4, Rect: rect}, nil
+	}
+
+	var set func(x, y int, pixel [4]uint16)
+	var img image.Image
+	switch {
+	case eightBit:
+		dst := image.NewNRGBA(rect)
+		set = func(x, y int, p [4]uint16) {
+			dst.SetNRGBA(x, y, color.NRGBA{uint8(p[0] >> 8), uint8(p[1] >> 8), uint8(p[2] >> 8), uint8(p[3] >> 8)})
+		}
+		img = dst
+	case len(channels) == 1:
+		dst := image.NewGray16(rect)
+		set = func(x, y int, p [4]uint16) {
+			dst.SetGray16(x, y, color.Gray16{p[0]})
+		}
+		img = dst
+	default:
+		dst := image.NewNRGBA64(rect)
+		set = func(x, y int, p [4]uint16) {
+			dst.SetNRGBA64(x, y, color.NRGBA64{p[0], p[1], p[2], p[3]})
+		}
+		img = dst
+	}
+
+	offset := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var pixel [4]uint16
+			for _, c := range channels {
+				pixel[c] = imageChannel(data[offset:], format.ChannelType)
+				offset += channelSize
+			}
+			set(x, y, pixel)
+		}
+	}
+
+	return img, nil
+}
+
+// straightRGBA 返回颜色的 16 位非预乘分量
+// 非预乘颜色直接取值，避免经过预乘再还原带来的精度损失；8 位格式写入时取高 8 位，
+// 结果与 color.NRGBAModel 一致。
+func straightRGBA(c color.Color) [4]uint16 {
+	switch c := c.(type) {
+	case color.NRGBA:
+		return [4]uint16{uint16(c.R) * 0x101, uint16(c.G) * 0x101, uint16(c.B) * 0x101, uint16(c.A) * 0x101}
+	case color.NRGBA64:
+		return [4]uint16{c.R, c.G, c.B, c.A}
+	}
+	p := color.NRGBA64Model.Convert(c).(color.NRGBA64)
+	return [4]uint16{p.R, p.G, p.B, p.A}
+}
+
+// putImageChannel 按通道类型写入一个 16 位归一化分量
+func putImageChannel(dst []byte, channelType UInt, v uint16) {
+	switch channelType {
+	case ChannelTypeUNormInt8:
+		dst[0] = uint8(v >> 8)
+	case ChannelTypeUNormInt16:
+		binary.LittleEndian.PutUint16(dst, v)
+	case ChannelTypeHalfFloat:
+		binary.LittleEndian.PutUint16(dst, float32ToHalf(float32(v)/0xffff))
+	case ChannelTypeFloat:
+		binary.LittleEndian.PutUint32(dst, math.Float32bits(float32(v)/0xffff))
+	}
+}
+
+// imageChannel 按通道类型读取一个分量，并转换为 16 位归一化值
+func imageChannel(src []byte, channelType UInt) uint16 {
+	switch channelType {
+	case ChannelTypeUNormInt8:
+		return uint16(src[0]) * 0x101
+	case ChannelTypeUNormInt16:
+		return binary.LittleEndian.Uint16(src)
+	case ChannelTypeHalfFloat:
+		return unormFromFloat(halfToFloat32(binary.LittleEndian.Uint16(src)))
+	case ChannelTypeFloat:
+		return unormFromFloat(math.Float32frombits(binary.LittleEndian.Uint32(src)))
+	}
+	return 0
+}
+
+// unormFromFloat 把浮点分量截断到 [0, 1] 并转换为 16 位归一化值
+func unormFromFloat(f float32) uint16 {
+	switch {
+	case !(f > 0): // 同时处理 NaN
+		return 0
+	case f >= 1:
+		return 0xffff
+	}
+	return uint16(f*0xffff + 0.5)
+}
+
+// float32ToHalf 把单精度浮点数转换为半精度（就近舍入）
+func float32ToHalf(f float32) uint16 {
+	bits := math.Float32bits(f)
+	sign := uint16(bits>>16) & 0x8000
+	exp := int(bits>>23&0xff) - 127 + 15
+	mant := bits & 0x7fffff
+
+	switch {
+	case bits>>23&0xff == 0xff:
+		// Inf 与 NaN
+		if mant != 0 {
+			return sign | 0x7e00
+		}
+		return sign | 0x7c00
+	case exp >= 0x1f:
+		return sign | 0x7c00
+	case exp <= 0:
+		// 非规格化数或下溢为 0
+		if exp < -10 {
+			return sign
+		}
+		mant |= 0x800000
+		shift := uint(14 - exp)
+		half := uint16(mant >> shift)
+		if mant>>(shift-1)&1 != 0 {
+			half++
+		}
+		return sign | half
+	}
+
+	half := sign | uint16(exp)<<10 | uint16(mant>>13)
+	if mant&0x1000 != 0 {
+		half++
+	}
+	return half
+}
+
+// halfToFloat32 把半精度浮点数转换为单精度
+func halfToFloat32(h uint16) float32 {
+	sign := uint32(h&0x8000) << 16
+	exp := uint32(h>>10) & 0x1f
+	mant := uint32(h & 0x3ff)
+
+	switch exp {
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case 0:
+		// 非规格化数: mant × 2^-24
+		f := float32(mant) / (1 << 24)
+		if sign != 0 {
+			f = -f
+		}
+		return f
+	}
+	return math.Float32frombits(sign | (exp+112)<<23 | mant<<13)
+}
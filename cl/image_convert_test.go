@@ -0,0 +1,236 @@
+package cl
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"math"
+	"testing"
+)
+
+// testNRGBA64 返回一个包含不透明、半透明、全透明以及分量大于 Alpha 的像素的图像
+func testNRGBA64(rect image.Rectangle) *image.NRGBA64 {
+	img := image.NewNRGBA64(rect)
+	colors := []color.NRGBA64{
+		{0xffff, 0x0000, 0x8080, 0xffff},
+		{0xffff, 0x4040, 0x0101, 0x8080},
+		{0x1212, 0x3434, 0x5656, 0x0000},
+		{0xfefe, 0xfefe, 0xfefe, 0x0101},
+	}
+	i := 0
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			img.SetNRGBA64(x, y, colors[i%len(colors)])
+			i++
+		}
+	}
+	return img
+}
+
+func TestImageRoundTripRGBA(t *testing.T) {
+	tests := []struct {
+		format    ImageFormat
+		want      string
+		tolerance int
+	}{
+		{ImageFormat{ChannelOrderRGBA, ChannelTypeUNormInt8}, "*image.NRGBA", 0},
+		{ImageFormat{ChannelOrderBGRA, ChannelTypeUNormInt8}, "*image.NRGBA", 0},
+		{ImageFormat{ChannelOrderRGBA, ChannelTypeUNormInt16}, "*image.NRGBA64", 0},
+		{ImageFormat{ChannelOrderBGRA, ChannelTypeUNormInt16}, "*image.NRGBA64", 0},
+		{ImageFormat{ChannelOrderRGBA, ChannelTypeFloat}, "*image.NRGBA64", 0},
+		// 半精度只有 11 位有效数字
+		{ImageFormat{ChannelOrderRGBA, ChannelTypeHalfFloat}, "*image.NRGBA64", 32},
+	}
+
+	src := testNRGBA64(image.Rect(2, 3, 6, 5))
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("order 0x%x type 0x%x", tt.format.ChannelOrder, tt.format.ChannelType), func(t *testing.T) {
+			data, err := encodeGoImage(src, tt.format)
+			if err != nil {
+				t.Fatalf("encodeGoImage: %v", err)
+			}
+			pixelSize, err := imagePixelSize(tt.format)
+			if err != nil {
+				t.Fatalf("imagePixelSize: %v", err)
+			}
+			if want := src.Bounds().Dx() * src.Bounds().Dy() * pixelSize; len(data) != want {
+				t.Fatalf("encoded %d bytes, want %d", len(data), want)
+			}
+
+			got, err := decodeGoImage(data, src.Bounds().Dx(), src.Bounds().Dy(), tt.format)
+			if err != nil {
+				t.Fatalf("decodeGoImage: %v", err)
+			}
+			if typ := fmt.Sprintf("%T", got); typ != tt.want {
+				t.Fatalf("decodeGoImage returned %s, want %s", typ, tt.want)
+			}
+
+			min := src.Bounds().Min
+			for y := 0; y < src.Bounds().Dy(); y++ {
+				for x := 0; x < src.Bounds().Dx(); x++ {
+					want := src.NRGBA64At(x+min.X, y+min.Y)
+					if tt.format.ChannelType == ChannelTypeUNormInt8 {
+						want = color.NRGBA64{want.R >> 8 * 0x101, want.G >> 8 * 0x101, want.B >> 8 * 0x101, want.A >> 8 * 0x101}
+					}
+					p := straightRGBA(got.At(x, y))
+					w := [4]uint16{want.R, want.G, want.B, want.A}
+					for c := range p {
+						if diff := int(p[c]) - int(w[c]); diff > tt.tolerance || -diff > tt.tolerance {
+							t.Fatalf("pixel (%d, %d) = %v, want %v", x, y, p, w)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestImageRoundTripGray(t *testing.T) {
+	formats := []ImageFormat{
+		{ChannelOrderR, ChannelTypeUNormInt8},
+		{ChannelOrderLuminance, ChannelTypeUNormInt8},
+		{ChannelOrderR, ChannelTypeUNormInt16},
+		{ChannelOrderLuminance, ChannelTypeUNormInt16},
+		{ChannelOrderR, ChannelTypeFloat},
+	}
+
+	src := image.NewGray16(image.Rect(0, 0, 3, 2))
+	for i, v := range []uint16{0x0000, 0x0101, 0x7f7f, 0x8080, 0xfefe, 0xffff} {
+		src.SetGray16(i%3, i/3, color.Gray16{v})
+	}
+
+	for _, format := range formats {
+		t.Run(fmt.Sprintf("order 0x%x type 0x%x", format.ChannelOrder, format.ChannelType), func(t *testing.T) {
+			data, err := encodeGoImage(src, format)
+			if err != nil {
+				t.Fatalf("encodeGoImage: %v", err)
+			}
+			got, err := decodeGoImage(data, 3, 2, format)
+			if err != nil {
+				t.Fatalf("decodeGoImage: %v", err)
+			}
+			for y := 0; y < 2; y++ {
+				for x := 0; x < 3; x++ {
+					want := src.Gray16At(x, y)
+					if g := color.Gray16Model.Convert(got.At(x, y)).(color.Gray16); g != want {
+						t.Errorf("pixel (%d, %d) = %v, want %v", x, y, g, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestImageAlphaConvention(t *testing.T) {
+	// 预乘的 50% 透明红色，设备端应得到非预乘的 (0xff, 0, 0, 0x80)
+	src := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	src.SetRGBA(0, 0, color.RGBA{0x80, 0x00, 0x00, 0x80})
+
+	formats := []ImageFormat{
+		{ChannelOrderRGBA, ChannelTypeUNormInt8},
+		{ChannelOrderBGRA, ChannelTypeUNormInt8},
+		{ChannelOrderRGBA, ChannelTypeUNormInt16},
+		{ChannelOrderRGBA, ChannelTypeFloat},
+		{ChannelOrderRGBA, ChannelTypeHalfFloat},
+	}
+	for _, format := range formats {
+		data, err := encodeGoImage(src, format)
+		if err != nil {
+			t.Fatalf("encodeGoImage(0x%x, 0x%x): %v", format.ChannelOrder, format.ChannelType, err)
+		}
+		got, err := decodeGoImage(data, 1, 1, format)
+		if err != nil {
+			t.Fatalf("decodeGoImage(0x%x, 0x%x): %v", format.ChannelOrder, format.ChannelType, err)
+		}
+		p := straightRGBA(got.At(0, 0))
+		if r, a := p[0]>>8, p[3]>>8; r != 0xff || a != 0x80 {
+			t.Errorf("format 0x%x/0x%x: device data is (r=0x%x, a=0x%x), want non-premultiplied (0xff, 0x80)",
+				format.ChannelOrder, format.ChannelType, r, a)
+		}
+	}
+}
+
+func TestDecodeNonPremultipliedRGBA8(t *testing.T) {
+	// 内核写出的非预乘数据，分量大于 Alpha
+	data := []byte{0xff, 0x80, 0x00, 0x40}
+	got, err := decodeGoImage(data, 1, 1, ImageFormat{ChannelOrderRGBA, ChannelTypeUNormInt8})
+	if err != nil {
+		t.Fatalf("decodeGoImage: %v", err)
+	}
+	nrgba, ok := got.(*image.NRGBA)
+	if !ok {
+		t.Fatalf("decodeGoImage returned %T, want *image.NRGBA", got)
+	}
+	if c := nrgba.NRGBAAt(0, 0); c != (color.NRGBA{0xff, 0x80, 0x00, 0x40}) {
+		t.Errorf("pixel = %v, want {255 128 0 64}", c)
+	}
+	if r, _, _, a := nrgba.At(0, 0).RGBA(); r > a {
+		t.Errorf("premultiplied red 0x%x exceeds alpha 0x%x", r, a)
+	}
+}
+
+func TestFloat32ToHalf(t *testing.T) {
+	tests := []struct {
+		in   float32
+		want uint16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.5, 0x3800},
+		{0.333251953125, 0x3555},
+		{65504, 0x7bff},
+		{65520, 0x7c00}, // 舍入后溢出为无穷大
+		{1e6, 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+		{6.103515625e-05, 0x0400},        // 最小规格化数
+		{5.9604644775390625e-08, 0x0001}, // 最小非规格化数
+		{1e-9, 0x0000},
+	}
+
+	for _, tt := range tests {
+		if got := float32ToHalf(tt.in); got != tt.want {
+			t.Errorf("float32ToHalf(%g) = 0x%04x, want 0x%04x", tt.in, got, tt.want)
+		}
+	}
+
+	if got := float32ToHalf(float32(math.NaN())); got&0x7c00 != 0x7c00 || got&0x3ff == 0 {
+		t.Errorf("float32ToHalf(NaN) = 0x%04x, want a NaN", got)
+	}
+}
+
+func TestHalfRoundTrip(t *testing.T) {
+	for h := 0; h <= 0xffff; h++ {
+		f := halfToFloat32(uint16(h))
+		if math.IsNaN(float64(f)) {
+			if h&0x7c00 != 0x7c00 || h&0x3ff == 0 {
+				t.Fatalf("halfToFloat32(0x%04x) = NaN", h)
+			}
+			continue
+		}
+		if got := float32ToHalf(f); got != uint16(h) {
+			t.Fatalf("float32ToHalf(halfToFloat32(0x%04x) = %g) = 0x%04x", h, f, got)
+		}
+	}
+}
+
+func TestUnormFromFloat(t *testing.T) {
+	tests := []struct {
+		in   float32
+		want uint16
+	}{
+		{-1, 0},
+		{0, 0},
+		{0.5, 0x8000},
+		{1, 0xffff},
+		{2, 0xffff},
+		{float32(math.NaN()), 0},
+	}
+
+	for _, tt := range tests {
+		if got := unormFromFloat(tt.in); got != tt.want {
+			t.Errorf("unormFromFloat(%g) = 0x%04x, want 0x%04x", tt.in, got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,8 @@ package cl
 */
 import "C"
 import (
+	"errors"
+	"fmt"
 	"unsafe"
 )
 
@@ -77,6 +79,8 @@ func ReleaseKernel(kernel Kernel) error {
 	if err != C.CL_SUCCESS {
 		return OpenCLError{Code: Int(err)}
 	}
+	// 内核句柄释放后可能被复用
+	kernelDevices.Delete(kernel)
 	return nil
 }
 func RetainKernel(kernel Kernel) error {
@@ -245,3 +249,195 @@ func GetKernelProgram(kernel Kernel) (Program, error) {
 	program := *(*C.cl_program)(unsafe.Pointer(&info[0]))
 	return Program(program), nil
 }
+
+// KernelArgInfo 内核参数元数据
+type KernelArgInfo struct {
+	Name             string // 参数名
+	TypeName         string // 类型名，如 "float*"
+	AddressQualifier UInt   // 地址空间限定符，KernelArgAddress*
+	AccessQualifier  UInt   // 访问限定符，KernelArgAccess*（仅图像参数有意义）
+	TypeQualifier    uint64 // 类型限定符位标志，KernelArgType*
+}
+
+// IsConst 参数是否带 const 限定
+func (a KernelArgInfo) IsConst() bool {
+	return a.TypeQualifier&KernelArgTypeConst != 0
+}
+
+// IsRestrict 参数是否带 restrict 限定
+func (a KernelArgInfo) IsRestrict() bool {
+	return a.TypeQualifier&KernelArgTypeRestrict != 0
+}
+
+// IsVolatile 参数是否带 volatile 限定
+func (a KernelArgInfo) IsVolatile() bool {
+	return a.TypeQualifier&KernelArgTypeVolatile != 0
+}
+
+// IsPipe 参数是否为 pipe
+func (a KernelArgInfo) IsPipe() bool {
+	return a.TypeQualifier&KernelArgTypePipe != 0
+}
+
+// AddressQualifierString 将地址空间限定符转换为字符串
+func AddressQualifierString(qualifier UInt) string {
+	switch qualifier {
+	case KernelArgAddressGlobal:
+		return "global"
+	case KernelArgAddressLocal:
+		return "local"
+	case KernelArgAddressConstant:
+		return "constant"
+	case KernelArgAddressPrivate:
+		return "private"
+	default:
+		return fmt.Sprintf("Unknown address qualifier (code: %d)", qualifier)
+	}
+}
+
+// AccessQualifierString 将访问限定符转换为字符串
+func AccessQualifierString(qualifier UInt) string {
+	switch qualifier {
+	case KernelArgAccessReadOnly:
+		return "read_only"
+	case KernelArgAccessWriteOnly:
+		return "write_only"
+	case KernelArgAccessReadWrite:
+		return "read_write"
+	case KernelArgAccessNone:
+		return "none"
+	default:
+		return fmt.Sprintf("Unknown access qualifier (code: %d)", qualifier)
+	}
+}
+
+// ErrKernelArgInfoNotAvailable 内核参数信息不可用
+// 程序需使用 -cl-kernel-arg-info 选项从源码构建，驱动才会保留参数名、类型名等元数据。
+var ErrKernelArgInfoNotAvailable = errors.New("cl: kernel argument info not available, build the program with -cl-kernel-arg-info")
+
+// GetKernelArgInfo 获取内核参数信息
+// 程序未使用 -cl-kernel-arg-info 选项构建时返回包装了 ErrKernelArgInfoNotAvailable 的错误。
+func GetKernelArgInfo(kernel Kernel, argIndex UInt, paramName UInt) ([]byte, error) {
+	var paramValueSizeRet C.size_t
+
+	// 第一次调用，获取需要的大小
+	err := C.clGetKernelArgInfo(
+		C.cl_kernel(kernel),
+		C.cl_uint(argIndex),
+		C.cl_kernel_arg_info(paramName),
+		0,
+		nil,
+		&paramValueSizeRet,
+	)
+	if err != C.CL_SUCCESS {
+		return nil, kernelArgInfoError(err)
+	}
+
+	if paramValueSizeRet == 0 {
+		return nil, nil
+	}
+
+	// 分配缓冲区
+	paramValue := make([]byte, paramValueSizeRet)
+
+	// 第二次调用，真正获取数据
+	err = C.clGetKernelArgInfo(
+		C.cl_kernel(kernel),
+		C.cl_uint(argIndex),
+		C.cl_kernel_arg_info(paramName),
+		paramValueSizeRet,
+		unsafe.Pointer(&paramValue[0]),
+		nil,
+	)
+	if err != C.CL_SUCCESS {
+		return nil, kernelArgInfoError(err)
+	}
+
+	return paramValue, nil
+}
+
+// kernelArgInfoError 把 CL_KERNEL_ARG_INFO_NOT_AVAILABLE 转换为 ErrKernelArgInfoNotAvailable
+func kernelArgInfoError(err C.cl_int) error {
+	if err == C.CL_KERNEL_ARG_INFO_NOT_AVAILABLE {
+		return fmt.Errorf("%w: %w", ErrKernelArgInfoNotAvailable, OpenCLError{Code: Int(err)})
+	}
+	return OpenCLError{Code: Int(err)}
+}
+
+// kernelArgInfoValue 读取固定大小的内核参数信息，驱动返回的数据长度不足时返回错误
+func kernelArgInfoValue[T any](kernel Kernel, argIndex UInt, paramName UInt) (T, error) {
+	var value T
+	info, err := GetKernelArgInfo(kernel, argIndex, paramName)
+	if err != nil {
+		return value, err
+	}
+	if uintptr(len(info)) < unsafe.Sizeof(value) {
+		return value, fmt.Errorf("cl: kernel arg info 0x%x returned %d bytes, want %d: %w",
+			paramName, len(info), unsafe.Sizeof(value), OpenCLError{Code: Int(C.CL_INVALID_VALUE)})
+	}
+	return *(*T)(unsafe.Pointer(&info[0])), nil
+}
+
+// GetKernelArg 获取单个内核参数的全部元数据
+func GetKernelArg(kernel Kernel, argIndex UInt) (*KernelArgInfo, error) {
+	arg := &KernelArgInfo{}
+
+	info, err := GetKernelArgInfo(kernel, argIndex, C.CL_KERNEL_ARG_NAME)
+	if err != nil {
+		return nil, err
+	}
+	arg.Name = trimNull(info)
+
+	info, err = GetKernelArgInfo(kernel, argIndex, C.CL_KERNEL_ARG_TYPE_NAME)
+	if err != nil {
+		return nil, err
+	}
+	arg.TypeName = trimNull(info)
+
+	addressQualifier, err := kernelArgInfoValue[C.cl_kernel_arg_address_qualifier](kernel, argIndex, C.CL_KERNEL_ARG_ADDRESS_QUALIFIER)
+	if err != nil {
+		return nil, err
+	}
+	arg.AddressQualifier = UInt(addressQualifier)
+
+	accessQualifier, err := kernelArgInfoValue[C.cl_kernel_arg_access_qualifier](kernel, argIndex, C.CL_KERNEL_ARG_ACCESS_QUALIFIER)
+	if err != nil {
+		return nil, err
+	}
+	arg.AccessQualifier = UInt(accessQualifier)
+
+	typeQualifier, err := kernelArgInfoValue[C.cl_kernel_arg_type_qualifier](kernel, argIndex, C.CL_KERNEL_ARG_TYPE_QUALIFIER)
+	if err != nil {
+		return nil, err
+	}
+	arg.TypeQualifier = uint64(typeQualifier)
+
+	return arg, nil
+}
+
+// GetKernelArgs 获取内核全部参数的元数据
+func GetKernelArgs(kernel Kernel) ([]KernelArgInfo, error) {
+	numArgs, err := GetKernelNumArgs(kernel)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]KernelArgInfo, numArgs)
+	for i := range args {
+		arg, err := GetKernelArg(kernel, UInt(i))
+		if err != nil {
+			return nil, err
+		}
+		args[i] = *arg
+	}
+
+	return args, nil
+}
+
+// trimNull 移除末尾的null字符
+func trimNull(info []byte) string {
+	if len(info) > 0 && info[len(info)-1] == 0 {
+		info = info[:len(info)-1]
+	}
+	return string(info)
+}
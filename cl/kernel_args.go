@@ -0,0 +1,207 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// OpenCL 向量类型，内存布局与内核中的同名类型一致（half 向量没有对应的 Go 类型，未提供）
+// 三分量向量在设备上按四分量对齐，作为参数直接传入时会自动补齐；放在结构体中时不会补齐，
+// 需要使用四分量类型。普通的 [3]T 数组按原样传递。
+type (
+	Char2    [2]int8
+	Char3    [3]int8
+	Char4    [4]int8
+	Char8    [8]int8
+	Char16   [16]int8
+	UChar2   [2]uint8
+	UChar3   [3]uint8
+	UChar4   [4]uint8
+	UChar8   [8]uint8
+	UChar16  [16]uint8
+	Short2   [2]int16
+	Short3   [3]int16
+	Short4   [4]int16
+	Short8   [8]int16
+	Short16  [16]int16
+	UShort2  [2]uint16
+	UShort3  [3]uint16
+	UShort4  [4]uint16
+	UShort8  [8]uint16
+	UShort16 [16]uint16
+	Int2     [2]int32
+	Int3     [3]int32
+	Int4     [4]int32
+	Int8     [8]int32
+	Int16    [16]int32
+	UInt2    [2]uint32
+	UInt3    [3]uint32
+	UInt4    [4]uint32
+	UInt8    [8]uint32
+	UInt16   [16]uint32
+	Long2    [2]int64
+	Long3    [3]int64
+	Long4    [4]int64
+	Long8    [8]int64
+	Long16   [16]int64
+	ULong2   [2]uint64
+	ULong3   [3]uint64
+	ULong4   [4]uint64
+	ULong8   [8]uint64
+	ULong16  [16]uint64
+	Float2   [2]float32
+	Float3   [3]float32
+	Float4   [4]float32
+	Float8   [8]float32
+	Float16  [16]float32
+	Double2  [2]float64
+	Double3  [3]float64
+	Double4  [4]float64
+	Double8  [8]float64
+	Double16 [16]float64
+)
+
+// vector3Types 需要补齐为四分量的三分量向量类型
+var vector3Types = map[reflect.Type]bool{
+	reflect.TypeOf(Char3{}):   true,
+	reflect.TypeOf(UChar3{}):  true,
+	reflect.TypeOf(Short3{}):  true,
+	reflect.TypeOf(UShort3{}): true,
+	reflect.TypeOf(Int3{}):    true,
+	reflect.TypeOf(UInt3{}):   true,
+	reflect.TypeOf(Long3{}):   true,
+	reflect.TypeOf(ULong3{}):  true,
+	reflect.TypeOf(Float3{}):  true,
+	reflect.TypeOf(Double3{}): true,
+}
+
+// LocalMemory 标记 __local 内核参数，值为分配的字节数
+type LocalMemory Size
+
+// KernelArgError 设置某个内核参数时发生的错误
+type KernelArgError struct {
+	Index int
+	Err   error
+}
+
+func (e *KernelArgError) Error() string {
+	return fmt.Sprintf("kernel argument %d: %v", e.Index, e.Err)
+}
+
+func (e *KernelArgError) Unwrap() error {
+	return e.Err
+}
+
+// SetArgs 按顺序设置内核的全部参数
+// 支持的参数类型:
+//   - 定长标量: int8/16/32/64、uint8/16/32/64、float32、float64 及以它们为底层类型的类型（如 Int、UInt）
+//   - 向量类型: Float4、Int3 等（三分量向量补齐为四分量），以及由标量组成的定长数组和结构体（按原样传递）
+//   - 不支持 bool（包括数组元素和结构体字段中的 bool），OpenCL C 不允许 bool 类型的内核参数
+//   - 内存对象: MemObject，或提供 MemObject() 方法的类型（如 *Buffer[T]）
+//   - 采样器: Sampler
+//   - LocalMemory(n): 为 __local 参数分配 n 字节
+//
+// 参数个数与 GetKernelNumArgs 不一致时直接返回错误；单个参数失败时返回 *KernelArgError。
+func SetArgs(kernel Kernel, args ...any) error {
+	numArgs, err := GetKernelNumArgs(kernel)
+	if err != nil {
+		return err
+	}
+	if int(numArgs) != len(args) {
+		name, _ := GetKernelFunctionName(kernel)
+		return fmt.Errorf("kernel %q expects %d arguments, got %d: %w",
+			name, numArgs, len(args), OpenCLError{Code: Int(C.CL_INVALID_KERNEL_ARGS)})
+	}
+
+	for i, arg := range args {
+		if err := SetArg(kernel, UInt(i), arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetArg 设置单个内核参数，支持的类型同 SetArgs
+func SetArg(kernel Kernel, index UInt, arg any) error {
+	size, value, err := kernelArgValue(arg)
+	if err != nil {
+		return &KernelArgError{Index: int(index), Err: err}
+	}
+	if err := SetKernelArg(kernel, index, size, value); err != nil {
+		return &KernelArgError{Index: int(index), Err: err}
+	}
+	return nil
+}
+
+// kernelArgValue 把 Go 值转换为 clSetKernelArg 需要的大小和指针
+func kernelArgValue(arg any) (Size, unsafe.Pointer, error) {
+	switch v := arg.(type) {
+	case nil:
+		return 0, nil, fmt.Errorf("nil argument")
+	case LocalMemory:
+		if v == 0 {
+			return 0, nil, fmt.Errorf("local memory size must be positive")
+		}
+		return Size(v), nil, nil
+	case MemObject:
+		return Size(unsafe.Sizeof(v)), unsafe.Pointer(&v), nil
+	case Sampler:
+		return Size(unsafe.Sizeof(v)), unsafe.Pointer(&v), nil
+	case interface{ MemObject() MemObject }:
+		mem := v.MemObject()
+		return Size(unsafe.Sizeof(mem)), unsafe.Pointer(&mem), nil
+	}
+
+	rv := reflect.ValueOf(arg)
+	t := rv.Type()
+	switch t.Kind() {
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
+		return 0, nil, fmt.Errorf("type %s has platform-dependent size, use a sized integer type", t)
+	}
+	if containsBool(t) {
+		return 0, nil, fmt.Errorf("type %s: OpenCL C does not allow bool in kernel arguments, use int8 or int32", t)
+	}
+	if !isPlainDataType(t) {
+		return 0, nil, fmt.Errorf("unsupported argument type %s", t)
+	}
+
+	// 三分量向量在设备上占用四个分量的空间
+	if vector3Types[t] {
+		padded := reflect.New(reflect.ArrayOf(4, t.Elem())).Elem()
+		reflect.Copy(padded, rv)
+		rv = padded
+		t = padded.Type()
+	}
+
+	ptr := reflect.New(t)
+	ptr.Elem().Set(rv)
+	return Size(t.Size()), ptr.UnsafePointer(), nil
+}
+
+// containsBool 判断类型本身或其数组元素、结构体字段中是否含有 bool
+// OpenCL C 禁止 bool 类型的内核参数，结构体中 bool 的大小也未定义。
+func containsBool(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool:
+		return true
+	case reflect.Array:
+		return containsBool(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if containsBool(t.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
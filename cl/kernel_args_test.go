@@ -0,0 +1,78 @@
+package cl
+
+import "testing"
+
+func TestKernelArgValueSize(t *testing.T) {
+	type vec3 struct {
+		V [3]float32
+	}
+
+	tests := []struct {
+		name string
+		arg  any
+		want Size
+	}{
+		{"int32", int32(1), 4},
+		{"Float3 is padded", Float3{1, 2, 3}, 16},
+		{"Int3 is padded", Int3{1, 2, 3}, 16},
+		{"Char3 is padded", Char3{1, 2, 3}, 4},
+		{"Double3 is padded", Double3{1, 2, 3}, 32},
+		{"plain [3]float32 is not padded", [3]float32{1, 2, 3}, 12},
+		{"struct with [3]float32 is not padded", vec3{}, 12},
+		{"Float4", Float4{}, 16},
+		{"Long16", Long16{}, 128},
+		{"LocalMemory", LocalMemory(64), 64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size, _, err := kernelArgValue(tt.arg)
+			if err != nil {
+				t.Fatalf("kernelArgValue(%#v): %v", tt.arg, err)
+			}
+			if size != tt.want {
+				t.Errorf("kernelArgValue(%#v) size = %d, want %d", tt.arg, size, tt.want)
+			}
+		})
+	}
+}
+
+func TestKernelArgValuePaddingKeepsValues(t *testing.T) {
+	size, ptr, err := kernelArgValue(Float3{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := *(*[4]float32)(ptr)
+	if size != 16 || got != [4]float32{1, 2, 3, 0} {
+		t.Errorf("kernelArgValue(Float3) = %d bytes %v, want 16 bytes [1 2 3 0]", size, got)
+	}
+}
+
+func TestKernelArgValueRejects(t *testing.T) {
+	type flags struct {
+		Enabled bool
+	}
+
+	tests := []struct {
+		name string
+		arg  any
+	}{
+		{"nil", nil},
+		{"bool", true},
+		{"bool array", [2]bool{}},
+		{"struct with bool", flags{}},
+		{"int", 1},
+		{"uint", uint(1)},
+		{"string", "x"},
+		{"slice", []float32{1}},
+		{"zero local memory", LocalMemory(0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := kernelArgValue(tt.arg); err == nil {
+				t.Errorf("kernelArgValue(%#v) succeeded, want error", tt.arg)
+			}
+		})
+	}
+}
@@ -61,6 +61,84 @@ func GetPlatformInfo(platform PlatformID, paramName UInt) (string, error) {
 	return string(buffer[:size-1]), nil // 去掉末尾的null字符
 }
 
+// getPlatformInfoBytes 获取平台信息的原始字节，用于数组等非字符串信息
+func getPlatformInfoBytes(platform PlatformID, paramName UInt) ([]byte, error) {
+	var size C.size_t
+	errCode := Int(C.clGetPlatformInfo(
+		C.cl_platform_id(platform),
+		C.cl_platform_info(paramName),
+		0, nil, &size))
+	if errCode != Success {
+		return nil, OpenCLError{Code: errCode}
+	}
+
+	if size == 0 {
+		return nil, nil
+	}
+
+	buffer := make([]byte, size)
+	errCode = Int(C.clGetPlatformInfo(
+		C.cl_platform_id(platform),
+		C.cl_platform_info(paramName),
+		size, unsafe.Pointer(&buffer[0]), nil))
+	if errCode != Success {
+		return nil, OpenCLError{Code: errCode}
+	}
+
+	return buffer, nil
+}
+
+// GetPlatformInfoUInt 获取平台UInt类型信息，例如 PlatformNumericVersion
+func GetPlatformInfoUInt(platform PlatformID, paramName UInt) (UInt, error) {
+	var value UInt
+	errCode := Int(C.clGetPlatformInfo(
+		C.cl_platform_id(platform),
+		C.cl_platform_info(paramName),
+		C.size_t(unsafe.Sizeof(value)),
+		unsafe.Pointer(&value), nil))
+	if errCode != Success {
+		return UInt(0), OpenCLError{Code: errCode}
+	}
+	return value, nil
+}
+
+// GetPlatformInfoULong 获取平台 cl_ulong 类型信息，例如 PlatformHostTimerResolution
+func GetPlatformInfoULong(platform PlatformID, paramName UInt) (uint64, error) {
+	var value C.cl_ulong
+	errCode := Int(C.clGetPlatformInfo(
+		C.cl_platform_id(platform),
+		C.cl_platform_info(paramName),
+		C.size_t(unsafe.Sizeof(value)),
+		unsafe.Pointer(&value), nil))
+	if errCode != Success {
+		return 0, OpenCLError{Code: errCode}
+	}
+	return uint64(value), nil
+}
+
+// GetPlatformInfoSlice 获取数组类型的平台信息，T 的大小需与参数对应的 C 元素类型一致
+func GetPlatformInfoSlice[T InfoElement](platform PlatformID, paramName UInt) ([]T, error) {
+	info, err := getPlatformInfoBytes(platform, paramName)
+	if err != nil {
+		return nil, err
+	}
+	return bytesToSlice[T](info), nil
+}
+
+// GetPlatformInfoNameVersions 获取 cl_name_version 数组类型的平台信息（需要 OpenCL 3.0），
+// 例如 PlatformExtensionsWithVersion
+func GetPlatformInfoNameVersions(platform PlatformID, paramName UInt) ([]NameVersion, error) {
+	if err := RequirePlatformVersion(platform, 3, 0); err != nil {
+		return nil, err
+	}
+	info, err := getPlatformInfoBytes(platform, paramName)
+	if err != nil {
+		return nil, err
+	}
+	return decodeNameVersions(info), nil
+}
+
+// GetPlatformDetails 获取平台完整信息，任一查询失败或版本字符串无法解析时返回错误
 func GetPlatformDetails(platform PlatformID) (*PlatformInfo, error) {
 	info := &PlatformInfo{ID: platform}
 
@@ -85,6 +163,12 @@ func GetPlatformDetails(platform PlatformID) (*PlatformInfo, error) {
 		}
 		f.assign(val)
 	}
+	info.ExtensionSet = NewExtensionSet(info.Extensions)
+	version, err := ParseVersion(info.Version)
+	if err != nil {
+		return nil, err
+	}
+	info.OpenCLVersion = version
 
 	return info, nil
 }
@@ -7,10 +7,15 @@ package cl
 #cgo linux pkg-config: OpenCL
 #include <CL/cl.h>
 #include <stdlib.h>
+
+extern void goBuildCallback(cl_program, void *);
 */
 import "C"
 import (
 	"fmt"
+	"runtime"
+	"strings"
+	"sync"
 	"unsafe"
 )
 
@@ -55,6 +60,33 @@ func CreateProgramWithSource(context Context, count UInt, strings []string, leng
 	return Program(program), nil
 }
 
+// CreateProgramWithIL 从中间语言（如 SPIR-V）创建程序，创建后仍需调用 BuildProgram
+// 上下文中没有 OpenCL 2.1 以上且支持 IL 的设备时返回包装了 ErrNotSupported 的错误（不使用 cl_khr_il_program 扩展），
+// 设备是否支持某种 IL 可通过 DeviceSupportsIL 查询。
+func CreateProgramWithIL(context Context, il []byte) (Program, error) {
+	var err C.cl_int
+
+	if len(il) == 0 {
+		return Program(nil), OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+	if err := requireContextIL(context, "clCreateProgramWithIL"); err != nil {
+		return Program(nil), err
+	}
+
+	program := C.clCreateProgramWithIL(
+		C.cl_context(context),
+		unsafe.Pointer(&il[0]),
+		C.size_t(len(il)),
+		&err,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Program(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Program(program), nil
+}
+
 func CreateProgramWithBinary(context Context, devices []DeviceID, lengths []Size, binaries [][]byte, binaryStatus []Int) (Program, error) {
 	var err C.cl_int
 
@@ -75,10 +107,13 @@ func CreateProgramWithBinary(context Context, devices []DeviceID, lengths []Size
 		lengthArray[i] = C.size_t(length)
 	}
 
-	// 准备二进制指针数组
+	// 准备二进制指针数组，指针数组本身位于 Go 内存中，所指向的二进制需要固定
+	var pinner runtime.Pinner
+	defer pinner.Unpin()
 	binaryPointers := make([]unsafe.Pointer, deviceCount)
 	for i, binary := range binaries {
 		if len(binary) > 0 {
+			pinner.Pin(&binary[0])
 			binaryPointers[i] = unsafe.Pointer(&binary[0])
 		} else {
 			binaryPointers[i] = nil
@@ -139,11 +174,330 @@ func BuildProgram(program Program, devices []DeviceID, options string, notify un
 	)
 
 	if err != C.CL_SUCCESS {
+		if err == C.CL_BUILD_PROGRAM_FAILURE {
+			return newProgramBuildError(program, devices, OpenCLError{Code: Int(err)})
+		}
+		return OpenCLError{Code: Int(err)}
+	}
+
+	return nil
+}
+
+// BuildProgramWithCallback 异步构建程序，构建结束（成功或失败）时调用 callback
+// 参数:
+//   - program: 程序
+//   - devices: 目标设备列表，为空时构建程序关联的全部设备
+//   - options: 构建选项
+//   - callback: 构建完成回调，在 OpenCL 运行时的线程中调用
+//
+// 返回:
+//   - error: 提交构建时的错误；构建本身的结果需在回调中通过 GetProgramBuildStatus 查询
+//
+// 返回错误（包括同步构建失败时的 *ProgramBuildError）时，callback 之后不会再被调用；
+// 规范不保证此时运行时是否调用回调，迟到的调用会被忽略。
+// 个别运行时会在 clBuildProgram 返回前同步调用回调，此时 callback 已经执行过。
+func BuildProgramWithCallback(program Program, devices []DeviceID, options string, callback func(Program)) error {
+	if callback == nil {
+		return OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+
+	var deviceCount C.cl_uint
+	var deviceArray *C.cl_device_id
+	if len(devices) > 0 {
+		deviceCount = C.cl_uint(len(devices))
+		deviceArray = (*C.cl_device_id)(unsafe.Pointer(&devices[0]))
+	}
+
+	var optionsPtr *C.char
+	if options != "" {
+		optionsPtr = C.CString(options)
+		defer C.free(unsafe.Pointer(optionsPtr))
+	}
+
+	slot := newOneShotHandle(callback)
+	defer releaseOneShotHandle(slot)
+
+	err := C.clBuildProgram(
+		C.cl_program(program),
+		deviceCount,
+		deviceArray,
+		optionsPtr,
+		(*[0]byte)(C.goBuildCallback),
+		slot,
+	)
+
+	if err != C.CL_SUCCESS {
+		// 取走句柄，之后运行时即使调用回调也不会再执行 callback
+		takeOneShotHandle(slot)
+		if err == C.CL_BUILD_PROGRAM_FAILURE {
+			return newProgramBuildError(program, devices, OpenCLError{Code: Int(err)})
+		}
+		return OpenCLError{Code: Int(err)}
+	}
+
+	return nil
+}
+
+// BuildProgramAsync 异步构建程序，返回的通道在构建结束后收到一个结果并关闭
+// 构建失败时结果为 *ProgramBuildError，其中包含构建日志与解析出的诊断信息。
+func BuildProgramAsync(program Program, devices []DeviceID, options string) <-chan error {
+	result := make(chan error, 1)
+
+	var once sync.Once
+	deliver := func(err error) {
+		once.Do(func() {
+			result <- err
+			close(result)
+		})
+	}
+
+	err := BuildProgramWithCallback(program, devices, options, func(p Program) {
+		deliver(checkProgramBuildStatus(p, devices))
+	})
+	if err != nil {
+		deliver(err)
+	}
+
+	return result
+}
+
+// checkProgramBuildStatus 检查程序在各设备上的构建状态
+func checkProgramBuildStatus(program Program, devices []DeviceID) error {
+	if len(devices) == 0 {
+		var err error
+		devices, err = GetProgramDevices(program)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, device := range devices {
+		status, err := GetProgramBuildStatus(program, device)
+		if err != nil {
+			return err
+		}
+		if C.cl_build_status(status) != C.CL_BUILD_SUCCESS {
+			return newProgramBuildError(program, devices, errBuildProgramFailure)
+		}
+	}
+
+	return nil
+}
+
+// GetProgramDevices 获取程序关联的设备列表，顺序与 CL_PROGRAM_BINARIES 等按设备返回的信息一致
+func GetProgramDevices(program Program) ([]DeviceID, error) {
+	info, err := GetProgramInfo(program, C.CL_PROGRAM_DEVICES)
+	if err != nil {
+		return nil, err
+	}
+
+	var devicePtr C.cl_device_id
+	deviceCount := len(info) / int(unsafe.Sizeof(devicePtr))
+	devices := make([]DeviceID, deviceCount)
+	for i := 0; i < deviceCount; i++ {
+		offset := i * int(unsafe.Sizeof(devicePtr))
+		devices[i] = DeviceID(*(*C.cl_device_id)(unsafe.Pointer(&info[offset])))
+	}
+
+	return devices, nil
+}
+
+// GetProgramBinaries 获取程序在各设备上的二进制，可保存后通过 CreateProgramWithBinary 恢复
+// 返回值以设备为键，设备列表同 GetProgramDevices；尚未为某设备构建时其二进制为空。
+func GetProgramBinaries(program Program) (map[DeviceID][]byte, error) {
+	devices, err := GetProgramDevices(program)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := GetProgramInfo(program, C.CL_PROGRAM_BINARY_SIZES)
+	if err != nil {
+		return nil, err
+	}
+	sizes := unsafe.Slice((*C.size_t)(unsafe.Pointer(&info[0])), len(info)/int(unsafe.Sizeof(C.size_t(0))))
+	if len(sizes) != len(devices) {
+		return nil, OpenCLError{Code: Int(C.CL_INVALID_PROGRAM)}
+	}
+
+	// 运行时把各设备的二进制写入调用方提供的缓冲区，缓冲区分配在 C 堆上
+	pointers := make([]unsafe.Pointer, len(devices))
+	for i, size := range sizes {
+		if size > 0 {
+			pointers[i] = C.malloc(size)
+		}
+	}
+	defer func() {
+		for _, ptr := range pointers {
+			if ptr != nil {
+				C.free(ptr)
+			}
+		}
+	}()
+
+	errCode := C.clGetProgramInfo(
+		C.cl_program(program),
+		C.CL_PROGRAM_BINARIES,
+		C.size_t(uintptr(len(pointers))*unsafe.Sizeof(pointers[0])),
+		unsafe.Pointer(&pointers[0]),
+		nil,
+	)
+	if errCode != C.CL_SUCCESS {
+		return nil, OpenCLError{Code: Int(errCode)}
+	}
+
+	binaries := make(map[DeviceID][]byte, len(devices))
+	for i, device := range devices {
+		if pointers[i] != nil {
+			binaries[device] = C.GoBytes(pointers[i], C.int(sizes[i]))
+		} else {
+			binaries[device] = nil
+		}
+	}
+
+	return binaries, nil
+}
+
+// CompileProgram 只编译程序源码，生成可供 LinkProgram 链接的目标对象
+// 参数:
+//   - program: 由 CreateProgramWithSource 创建的程序
+//   - devices: 目标设备列表，为空时编译程序关联的全部设备
+//   - options: 编译选项
+//   - headers: 嵌入头文件，键为源码中 #include 使用的名称，值为包含头文件源码的程序
+//
+// 返回:
+//   - error: 错误信息，编译失败时为 *ProgramBuildError，其中包含编译日志与解析出的诊断信息
+func CompileProgram(program Program, devices []DeviceID, options string, headers map[string]Program) error {
+	if err := requireDevicesVersion(devices, 1, 2, "clCompileProgram"); err != nil {
+		return err
+	}
+
+	var deviceCount C.cl_uint
+	var deviceArray *C.cl_device_id
+	if len(devices) > 0 {
+		deviceCount = C.cl_uint(len(devices))
+		deviceArray = (*C.cl_device_id)(unsafe.Pointer(&devices[0]))
+	}
+
+	var optionsPtr *C.char
+	if options != "" {
+		optionsPtr = C.CString(options)
+		defer C.free(unsafe.Pointer(optionsPtr))
+	}
+
+	// 头文件名称与程序数组一一对应
+	var headerCount C.cl_uint
+	var headerArray *C.cl_program
+	var headerNamesArray **C.char
+	if len(headers) > 0 {
+		headerCount = C.cl_uint(len(headers))
+		names := make([]*C.char, 0, len(headers))
+		programs := make([]C.cl_program, 0, len(headers))
+		for name, header := range headers {
+			names = append(names, C.CString(name))
+			programs = append(programs, C.cl_program(header))
+		}
+		defer func() {
+			for _, name := range names {
+				C.free(unsafe.Pointer(name))
+			}
+		}()
+
+		headerArray = &programs[0]
+		headerNamesArray = &names[0]
+	}
+
+	err := C.clCompileProgram(
+		C.cl_program(program),
+		deviceCount,
+		deviceArray,
+		optionsPtr,
+		headerCount,
+		headerArray,
+		headerNamesArray,
+		nil, // 同步编译
+		nil,
+	)
+
+	if err != C.CL_SUCCESS {
+		if err == C.CL_COMPILE_PROGRAM_FAILURE {
+			return newProgramBuildError(program, devices, OpenCLError{Code: Int(err)})
+		}
 		return OpenCLError{Code: Int(err)}
 	}
 
 	return nil
 }
+
+// LinkProgram 把多个已编译的目标对象或库链接为可执行程序
+// 参数:
+//   - context: 上下文
+//   - devices: 目标设备列表，为空时链接到上下文中的全部设备
+//   - options: 链接选项
+//   - programs: CompileProgram 生成的目标对象或 CreateLibrary 生成的库
+//
+// 返回:
+//   - Program: 链接得到的程序
+//   - error: 错误信息
+//
+// 链接失败（CL_LINK_PROGRAM_FAILURE）时运行时仍可能返回程序对象，此时返回的 Program 非空，
+// 可用 GetProgramBuildLog 查看链接日志，使用完毕后需调用 ReleaseProgram。
+func LinkProgram(context Context, devices []DeviceID, options string, programs []Program) (Program, error) {
+	var err C.cl_int
+
+	if len(programs) == 0 {
+		return Program(nil), OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+	if len(devices) > 0 {
+		if err := requireDevicesVersion(devices, 1, 2, "clLinkProgram"); err != nil {
+			return Program(nil), err
+		}
+	} else if err := requireContextVersion(context, 1, 2, "clLinkProgram"); err != nil {
+		return Program(nil), err
+	}
+
+	var deviceCount C.cl_uint
+	var deviceArray *C.cl_device_id
+	if len(devices) > 0 {
+		deviceCount = C.cl_uint(len(devices))
+		deviceArray = (*C.cl_device_id)(unsafe.Pointer(&devices[0]))
+	}
+
+	var optionsPtr *C.char
+	if options != "" {
+		optionsPtr = C.CString(options)
+		defer C.free(unsafe.Pointer(optionsPtr))
+	}
+
+	programArray := make([]C.cl_program, len(programs))
+	for i, p := range programs {
+		programArray[i] = C.cl_program(p)
+	}
+
+	program := C.clLinkProgram(
+		C.cl_context(context),
+		deviceCount,
+		deviceArray,
+		optionsPtr,
+		C.cl_uint(len(programArray)),
+		&programArray[0],
+		nil, // 同步链接
+		nil,
+		&err,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Program(program), OpenCLError{Code: Int(err)}
+	}
+
+	return Program(program), nil
+}
+
+// CreateLibrary 把多个已编译的目标对象链接为库，库可再作为 LinkProgram 的输入
+// 等价于在链接选项中加入 -create-library，返回值约定同 LinkProgram。
+func CreateLibrary(context Context, devices []DeviceID, options string, programs []Program) (Program, error) {
+	return LinkProgram(context, devices, strings.TrimSpace("-create-library "+options), programs)
+}
+
 func ReleaseProgram(program Program) error {
 	err := C.clReleaseProgram(C.cl_program(program))
 	if err != C.CL_SUCCESS {
@@ -336,30 +690,30 @@ func GetProgramBuildGlobalVariableTotalSize(program Program, device DeviceID) (S
 
 // BuildStatusString 将构建状态转换为字符串
 func BuildStatusString(status UInt) string {
-	switch status {
-	case 0: // CL_BUILD_SUCCESS
+	switch Int(status) {
+	case BuildStatusSuccess:
 		return "Build Success"
-	case 1: // CL_BUILD_NONE
+	case BuildStatusNone:
 		return "Build None"
-	case 2: // CL_BUILD_ERROR
+	case BuildStatusError:
 		return "Build Error"
-	case 3: // CL_BUILD_IN_PROGRESS
+	case BuildStatusInProgress:
 		return "Build In Progress"
 	default:
-		return fmt.Sprintf("Unknown build status (code: %d)", status)
+		return fmt.Sprintf("Unknown build status (code: %d)", Int(status))
 	}
 }
 
 // BinaryTypeString 将二进制类型转换为字符串
 func BinaryTypeString(binaryType UInt) string {
 	switch binaryType {
-	case 0: // CL_PROGRAM_BINARY_TYPE_NONE
+	case ProgramBinaryTypeNone:
 		return "None"
-	case 1: // CL_PROGRAM_BINARY_TYPE_COMPILED_OBJECT
+	case ProgramBinaryTypeCompiledObject:
 		return "Compiled Object"
-	case 2: // CL_PROGRAM_BINARY_TYPE_LIBRARY
+	case ProgramBinaryTypeLibrary:
 		return "Library"
-	case 3: // CL_PROGRAM_BINARY_TYPE_EXECUTABLE
+	case ProgramBinaryTypeExecutable:
 		return "Executable"
 	default:
 		return fmt.Sprintf("Unknown binary type (code: %d)", binaryType)
@@ -406,17 +760,17 @@ type BuildInfo struct {
 
 // IsBuildSuccessful 检查构建是否成功
 func (bi *BuildInfo) IsBuildSuccessful() bool {
-	return bi.Status == 0 // CL_BUILD_SUCCESS
+	return Int(bi.Status) == BuildStatusSuccess
 }
 
 // HasBuildErrors 检查是否有构建错误
 func (bi *BuildInfo) HasBuildErrors() bool {
-	return bi.Status == 2 // CL_BUILD_ERROR
+	return Int(bi.Status) == BuildStatusError
 }
 
 // IsBuilding 检查是否正在构建
 func (bi *BuildInfo) IsBuilding() bool {
-	return bi.Status == 3 // CL_BUILD_IN_PROGRESS
+	return Int(bi.Status) == BuildStatusInProgress
 }
 
 // String 返回构建信息的字符串表示
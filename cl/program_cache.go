@@ -0,0 +1,257 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// programCacheMagic 缓存文件头，格式变化时需要修改
+var programCacheMagic = []byte("GOCLBIN\x01")
+
+// ProgramCache 基于磁盘的程序二进制缓存
+// 缓存键由源码哈希、构建选项以及各设备的名称、厂商、驱动版本和 OpenCL 版本组成，
+// 任一项变化都会重新构建。缓存文件保存 CL_PROGRAM_BINARIES 的内容，下次启动时
+// 通过 CreateProgramWithBinary 恢复，旧二进制被运行时拒绝或构建失败时自动从源码重建。
+type ProgramCache struct {
+	dir string
+}
+
+// NewProgramCache 创建以 dir 为目录的程序缓存，目录不存在时自动创建
+func NewProgramCache(dir string) (*ProgramCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cl: create program cache directory: %w", err)
+	}
+	return &ProgramCache{dir: dir}, nil
+}
+
+// Dir 返回缓存目录
+func (c *ProgramCache) Dir() string {
+	return c.dir
+}
+
+// Build 构建程序，优先使用缓存的二进制
+// 参数:
+//   - context: 上下文
+//   - devices: 目标设备列表，不能为空
+//   - source: 程序源码
+//   - options: 构建选项
+//
+// 返回:
+//   - Program: 构建完成的程序
+//   - error: 错误信息
+//
+// 缓存的二进制无法创建或构建程序时（驱动升级后二进制失效、选项改变链接方式等），
+// 自动从源码重建并覆盖缓存项。
+// 从源码构建失败时返回的 Program 非空，可用 GetProgramBuildLog 查看日志，使用完毕后需调用 ReleaseProgram。
+// 写入缓存失败不会影响构建结果，只是下次启动仍需从源码构建。
+func (c *ProgramCache) Build(context Context, devices []DeviceID, source string, options string) (Program, error) {
+	if len(devices) == 0 {
+		return Program(nil), OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+
+	key, err := programCacheKey(devices, source, options)
+	if err != nil {
+		return Program(nil), err
+	}
+
+	return c.build(filepath.Join(c.dir, key+".bin"), len(devices), programCacheSteps{
+		fromBinaries: func(binaries [][]byte) (Program, error) {
+			return buildProgramFromBinaries(context, devices, binaries, options)
+		},
+		fromSource: func() (Program, error) {
+			program, err := CreateProgramWithSource(context, 1, []string{source}, nil)
+			if err != nil {
+				return Program(nil), err
+			}
+			if err := BuildProgram(program, devices, options, nil, nil); err != nil {
+				return program, err
+			}
+			return program, nil
+		},
+		binaries: func(program Program) ([][]byte, error) {
+			binaries, err := GetProgramBinaries(program)
+			if err != nil {
+				return nil, err
+			}
+			ordered := make([][]byte, len(devices))
+			for i, device := range devices {
+				ordered[i] = binaries[device]
+			}
+			return ordered, nil
+		},
+	})
+}
+
+// programCacheSteps Build 中与 OpenCL 运行时交互的步骤
+type programCacheSteps struct {
+	fromBinaries func(binaries [][]byte) (Program, error) // 从缓存的二进制创建并构建程序，失败时需释放程序
+	fromSource   func() (Program, error)                  // 从源码创建并构建程序
+	binaries     func(program Program) ([][]byte, error)  // 按设备顺序取出构建好的二进制
+}
+
+// build 读取缓存，缓存缺失或失效时从源码构建并写入缓存
+func (c *ProgramCache) build(path string, deviceCount int, steps programCacheSteps) (Program, error) {
+	if binaries, err := readProgramCache(path, deviceCount); err == nil {
+		program, err := steps.fromBinaries(binaries)
+		if err == nil {
+			return program, nil
+		}
+		// 缓存项失效，删除后从源码重建；重建成功时会写入新的缓存项
+		os.Remove(path)
+	}
+
+	program, err := steps.fromSource()
+	if err != nil {
+		return program, err
+	}
+
+	if binaries, err := steps.binaries(program); err == nil {
+		_ = c.write(path, binaries)
+	}
+
+	return program, nil
+}
+
+// Remove 删除指定源码、选项和设备对应的缓存项
+func (c *ProgramCache) Remove(devices []DeviceID, source string, options string) error {
+	key, err := programCacheKey(devices, source, options)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(c.dir, key+".bin"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// write 先写入临时文件再重命名，避免并发进程读到不完整的缓存
+func (c *ProgramCache) write(path string, binaries [][]byte) error {
+	for _, b := range binaries {
+		if len(b) == 0 {
+			// 某个设备没有二进制，缓存无法用于恢复
+			return OpenCLError{Code: Int(C.CL_INVALID_BINARY)}
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.Write(programCacheMagic)
+	binary.Write(&buf, binary.LittleEndian, uint32(len(binaries)))
+	for _, b := range binaries {
+		binary.Write(&buf, binary.LittleEndian, uint64(len(b)))
+		buf.Write(b)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// readProgramCache 读取缓存文件，返回按设备顺序排列的二进制
+func readProgramCache(path string, deviceCount int) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bytes.NewReader(data)
+	magic := make([]byte, len(programCacheMagic))
+	if _, err := io.ReadFull(r, magic); err != nil || !bytes.Equal(magic, programCacheMagic) {
+		return nil, fmt.Errorf("cl: invalid program cache file %s", path)
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	if int(count) != deviceCount {
+		return nil, fmt.Errorf("cl: program cache file %s has %d binaries, want %d", path, count, deviceCount)
+	}
+
+	binaries := make([][]byte, count)
+	for i := range binaries {
+		var size uint64
+		if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+			return nil, err
+		}
+		if size == 0 || size > uint64(r.Len()) {
+			return nil, fmt.Errorf("cl: truncated program cache file %s", path)
+		}
+		binaries[i] = make([]byte, size)
+		if _, err := io.ReadFull(r, binaries[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return binaries, nil
+}
+
+// buildProgramFromBinaries 从二进制创建并构建程序，失败时释放程序
+func buildProgramFromBinaries(context Context, devices []DeviceID, binaries [][]byte, options string) (Program, error) {
+	lengths := make([]Size, len(binaries))
+	for i, b := range binaries {
+		lengths[i] = Size(len(b))
+	}
+
+	program, err := CreateProgramWithBinary(context, devices, lengths, binaries, nil)
+	if err != nil {
+		return Program(nil), err
+	}
+	if err := BuildProgram(program, devices, options, nil, nil); err != nil {
+		ReleaseProgram(program)
+		return Program(nil), err
+	}
+
+	return program, nil
+}
+
+// programCacheKey 计算缓存键
+func programCacheKey(devices []DeviceID, source string, options string) (string, error) {
+	h := sha256.New()
+	sourceHash := sha256.Sum256([]byte(source))
+	h.Write(sourceHash[:])
+	fmt.Fprintf(h, "\x00%s\x00%d", options, len(devices))
+
+	for _, device := range devices {
+		for _, param := range []UInt{DeviceName, DeviceVendor, DeviceDriverVersion, DeviceVersion} {
+			value, err := GetDeviceInfo(device, param)
+			if err != nil {
+				return "", err
+			}
+			fmt.Fprintf(h, "\x00%s", value)
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,157 @@
+package cl
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// fakeProgramCacheSteps 记录调用次数的 programCacheSteps，不依赖 OpenCL 运行时
+type fakeProgramCacheSteps struct {
+	binaryErr    error
+	sourceErr    error
+	fresh        [][]byte
+	gotBinaries  [][]byte
+	binaryBuilds int
+	sourceBuilds int
+}
+
+func (f *fakeProgramCacheSteps) steps() programCacheSteps {
+	return programCacheSteps{
+		fromBinaries: func(binaries [][]byte) (Program, error) {
+			f.binaryBuilds++
+			f.gotBinaries = binaries
+			return Program(nil), f.binaryErr
+		},
+		fromSource: func() (Program, error) {
+			f.sourceBuilds++
+			return Program(nil), f.sourceErr
+		},
+		binaries: func(Program) ([][]byte, error) {
+			return f.fresh, nil
+		},
+	}
+}
+
+func newTestProgramCache(t *testing.T) (*ProgramCache, string) {
+	t.Helper()
+	cache, err := NewProgramCache(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cache, filepath.Join(cache.Dir(), "key.bin")
+}
+
+func TestProgramCacheMissWritesEntry(t *testing.T) {
+	cache, path := newTestProgramCache(t)
+	fake := &fakeProgramCacheSteps{fresh: [][]byte{[]byte("fresh")}}
+
+	if _, err := cache.build(path, 1, fake.steps()); err != nil {
+		t.Fatal(err)
+	}
+	if fake.binaryBuilds != 0 || fake.sourceBuilds != 1 {
+		t.Fatalf("binary builds = %d, source builds = %d, want 0, 1", fake.binaryBuilds, fake.sourceBuilds)
+	}
+
+	got, err := readProgramCache(path, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got[0], []byte("fresh")) {
+		t.Fatalf("cached binary = %q, want %q", got[0], "fresh")
+	}
+}
+
+func TestProgramCacheHit(t *testing.T) {
+	cache, path := newTestProgramCache(t)
+	if err := cache.write(path, [][]byte{[]byte("cached")}); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeProgramCacheSteps{}
+
+	if _, err := cache.build(path, 1, fake.steps()); err != nil {
+		t.Fatal(err)
+	}
+	if fake.binaryBuilds != 1 || fake.sourceBuilds != 0 {
+		t.Fatalf("binary builds = %d, source builds = %d, want 1, 0", fake.binaryBuilds, fake.sourceBuilds)
+	}
+	if !bytes.Equal(fake.gotBinaries[0], []byte("cached")) {
+		t.Fatalf("binaries = %q, want %q", fake.gotBinaries[0], "cached")
+	}
+}
+
+func TestProgramCacheStaleEntryIsRebuilt(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		// clCreateProgramWithBinary 拒绝旧二进制
+		{"invalid binary", OpenCLError{Code: -42}}, // CL_INVALID_BINARY
+		// 二进制被接受但 clBuildProgram 失败
+		{"build failure", &ProgramBuildError{Err: errBuildProgramFailure}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache, path := newTestProgramCache(t)
+			if err := cache.write(path, [][]byte{[]byte("stale")}); err != nil {
+				t.Fatal(err)
+			}
+			fake := &fakeProgramCacheSteps{binaryErr: tt.err, fresh: [][]byte{[]byte("fresh")}}
+
+			if _, err := cache.build(path, 1, fake.steps()); err != nil {
+				t.Fatalf("build returned %v, want rebuild from source", err)
+			}
+			if fake.binaryBuilds != 1 || fake.sourceBuilds != 1 {
+				t.Fatalf("binary builds = %d, source builds = %d, want 1, 1", fake.binaryBuilds, fake.sourceBuilds)
+			}
+
+			got, err := readProgramCache(path, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got[0], []byte("fresh")) {
+				t.Fatalf("cached binary = %q, want %q", got[0], "fresh")
+			}
+		})
+	}
+}
+
+func TestProgramCacheSourceFailureLeavesNoEntry(t *testing.T) {
+	cache, path := newTestProgramCache(t)
+	if err := cache.write(path, [][]byte{[]byte("stale")}); err != nil {
+		t.Fatal(err)
+	}
+	sourceErr := &ProgramBuildError{Err: errBuildProgramFailure}
+	fake := &fakeProgramCacheSteps{
+		binaryErr: &ProgramBuildError{Err: errBuildProgramFailure},
+		sourceErr: sourceErr,
+		fresh:     [][]byte{[]byte("fresh")},
+	}
+
+	if _, err := cache.build(path, 1, fake.steps()); !errors.Is(err, sourceErr) {
+		t.Fatalf("build returned %v, want %v", err, sourceErr)
+	}
+	if _, err := readProgramCache(path, 1); err == nil {
+		t.Fatal("stale entry still present after failed rebuild")
+	}
+}
+
+func TestReadProgramCacheRejectsCorruptFiles(t *testing.T) {
+	cache, path := newTestProgramCache(t)
+	if err := cache.write(path, [][]byte{[]byte("a"), []byte("bc")}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readProgramCache(path, 1); err == nil {
+		t.Fatal("device count mismatch not detected")
+	}
+	got, err := readProgramCache(path, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got[0]) != "a" || string(got[1]) != "bc" {
+		t.Fatalf("binaries = %q", got)
+	}
+}
@@ -69,6 +69,8 @@ func ReleaseCommandQueue(queue CommandQueue) error {
 	if err != C.CL_SUCCESS {
 		return OpenCLError{Code: Int(err)}
 	}
+	// 队列句柄释放后可能被复用
+	queueDevices.Delete(queue)
 	return nil
 }
 
@@ -276,6 +278,10 @@ func EnqueueMarker(queue CommandQueue, event *Event) error {
 	var err C.cl_int
 	var eventPtr *C.cl_event
 
+	if err := requireQueueVersion(queue, 1, 2, "clEnqueueMarkerWithWaitList"); err != nil {
+		return err
+	}
+
 	if event != nil {
 		eventPtr = (*C.cl_event)(unsafe.Pointer(event))
 	}
@@ -296,6 +302,10 @@ func EnqueueMarker(queue CommandQueue, event *Event) error {
 
 // EnqueueBarrier 在命令队列中插入屏障
 func EnqueueBarrier(queue CommandQueue) error {
+	if err := requireQueueVersion(queue, 1, 2, "clEnqueueBarrierWithWaitList"); err != nil {
+		return err
+	}
+
 	// 新接口同样接受等待列表；这里直接传 0 / NULL
 	err := C.clEnqueueBarrierWithWaitList(
 		C.cl_command_queue(queue),
@@ -0,0 +1,227 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+// clCreateSampler 在 OpenCL 2.0 中被弃用，但 1.x 设备只能使用它
+#define CL_USE_DEPRECATED_OPENCL_1_2_APIS
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// CreateSampler 创建采样器
+// 参数:
+//   - context: 上下文
+//   - normalizedCoords: 是否使用 [0, 1] 归一化坐标
+//   - addressingMode: 寻址模式（AddressClampToEdge、AddressClamp、AddressRepeat、AddressMirroredRepeat 或 AddressNone）
+//   - filterMode: 过滤模式（FilterNearest 或 FilterLinear）
+//
+// 返回:
+//   - Sampler: 创建的采样器
+//   - error: 错误信息
+//
+// 上下文中有 OpenCL 2.0 设备时使用 clCreateSamplerWithProperties，否则回退到 clCreateSampler。
+func CreateSampler(context Context, normalizedCoords bool, addressingMode UInt, filterMode UInt) (Sampler, error) {
+	var normalized UInt
+	if normalizedCoords {
+		normalized = C.CL_TRUE
+	}
+
+	if err := requireContextVersion(context, 2, 0, "clCreateSamplerWithProperties"); err == nil {
+		return createSamplerWithProperties(context, map[UInt]any{
+			SamplerNormalizedCoords: normalized,
+			SamplerAddressingMode:   addressingMode,
+			SamplerFilterMode:       filterMode,
+		})
+	} else if !errors.Is(err, ErrNotSupported) {
+		return Sampler(nil), err
+	}
+
+	var err C.cl_int
+	sampler := C.clCreateSampler(
+		C.cl_context(context),
+		C.cl_bool(normalized),
+		C.cl_addressing_mode(addressingMode),
+		C.cl_filter_mode(filterMode),
+		&err,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Sampler(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Sampler(sampler), nil
+}
+
+// CreateSamplerWithProperties 根据属性表创建采样器（需要 OpenCL 2.0）
+// 属性名为 SamplerNormalizedCoords、SamplerAddressingMode、SamplerFilterMode，未指定的属性使用默认值。
+// 上下文中没有 OpenCL 2.0 设备时返回包装了 ErrNotSupported 的错误，此时可使用 CreateSampler。
+func CreateSamplerWithProperties(context Context, properties map[UInt]any) (Sampler, error) {
+	if err := requireContextVersion(context, 2, 0, "clCreateSamplerWithProperties"); err != nil {
+		return Sampler(nil), err
+	}
+	return createSamplerWithProperties(context, properties)
+}
+
+func createSamplerWithProperties(context Context, properties map[UInt]any) (Sampler, error) {
+	var err C.cl_int
+	var propsPtr *C.cl_sampler_properties
+
+	if len(properties) > 0 {
+		// 每个属性是 key+value 两个元素，最后还要加一个 0 作为结束符
+		propertiesArray := make([]C.cl_sampler_properties, 0, len(properties)*2+1)
+
+		for key, value := range properties {
+			propertiesArray = append(propertiesArray, C.cl_sampler_properties(key))
+			switch v := value.(type) {
+			case UInt:
+				propertiesArray = append(propertiesArray, C.cl_sampler_properties(v))
+			case Bool:
+				propertiesArray = append(propertiesArray, C.cl_sampler_properties(v))
+			case bool:
+				if v {
+					propertiesArray = append(propertiesArray, C.CL_TRUE)
+				} else {
+					propertiesArray = append(propertiesArray, C.CL_FALSE)
+				}
+			case int:
+				propertiesArray = append(propertiesArray, C.cl_sampler_properties(v))
+			default:
+				return Sampler(nil), OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+			}
+		}
+
+		// 结束标记
+		propertiesArray = append(propertiesArray, 0)
+		propsPtr = &propertiesArray[0]
+	}
+
+	sampler := C.clCreateSamplerWithProperties(
+		C.cl_context(context),
+		propsPtr,
+		&err,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Sampler(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Sampler(sampler), nil
+}
+
+// RetainSampler 增加采样器的引用计数
+func RetainSampler(sampler Sampler) error {
+	err := C.clRetainSampler(C.cl_sampler(sampler))
+	if err != C.CL_SUCCESS {
+		return OpenCLError{Code: Int(err)}
+	}
+	return nil
+}
+
+// ReleaseSampler 释放采样器
+func ReleaseSampler(sampler Sampler) error {
+	err := C.clReleaseSampler(C.cl_sampler(sampler))
+	if err != C.CL_SUCCESS {
+		return OpenCLError{Code: Int(err)}
+	}
+	return nil
+}
+
+// GetSamplerInfo 获取采样器信息
+func GetSamplerInfo(sampler Sampler, paramName UInt) ([]byte, error) {
+	var paramValueSizeRet C.size_t
+
+	// 第一次调用，获取需要的大小
+	err := C.clGetSamplerInfo(
+		C.cl_sampler(sampler),
+		C.cl_sampler_info(paramName),
+		0,
+		nil,
+		&paramValueSizeRet,
+	)
+	if err != C.CL_SUCCESS {
+		return nil, OpenCLError{Code: Int(err)}
+	}
+
+	if paramValueSizeRet == 0 {
+		return nil, nil
+	}
+
+	paramValue := make([]byte, paramValueSizeRet)
+
+	// 第二次调用，真正获取数据
+	err = C.clGetSamplerInfo(
+		C.cl_sampler(sampler),
+		C.cl_sampler_info(paramName),
+		paramValueSizeRet,
+		unsafe.Pointer(&paramValue[0]),
+		nil,
+	)
+	if err != C.CL_SUCCESS {
+		return nil, OpenCLError{Code: Int(err)}
+	}
+
+	return paramValue, nil
+}
+
+// GetSamplerNormalizedCoords 采样器是否使用归一化坐标
+func GetSamplerNormalizedCoords(sampler Sampler) (bool, error) {
+	info, err := GetSamplerInfo(sampler, C.CL_SAMPLER_NORMALIZED_COORDS)
+	if err != nil {
+		return false, err
+	}
+
+	normalized := *(*C.cl_bool)(unsafe.Pointer(&info[0]))
+	return normalized == C.CL_TRUE, nil
+}
+
+// GetSamplerAddressingMode 获取采样器寻址模式
+func GetSamplerAddressingMode(sampler Sampler) (UInt, error) {
+	info, err := GetSamplerInfo(sampler, C.CL_SAMPLER_ADDRESSING_MODE)
+	if err != nil {
+		return 0, err
+	}
+
+	mode := *(*C.cl_addressing_mode)(unsafe.Pointer(&info[0]))
+	return UInt(mode), nil
+}
+
+// GetSamplerFilterMode 获取采样器过滤模式
+func GetSamplerFilterMode(sampler Sampler) (UInt, error) {
+	info, err := GetSamplerInfo(sampler, C.CL_SAMPLER_FILTER_MODE)
+	if err != nil {
+		return 0, err
+	}
+
+	mode := *(*C.cl_filter_mode)(unsafe.Pointer(&info[0]))
+	return UInt(mode), nil
+}
+
+// GetSamplerContext 获取采样器关联的上下文
+func GetSamplerContext(sampler Sampler) (Context, error) {
+	info, err := GetSamplerInfo(sampler, C.CL_SAMPLER_CONTEXT)
+	if err != nil {
+		return Context(nil), err
+	}
+
+	context := *(*C.cl_context)(unsafe.Pointer(&info[0]))
+	return Context(context), nil
+}
+
+// GetSamplerReferenceCount 获取采样器引用计数
+func GetSamplerReferenceCount(sampler Sampler) (UInt, error) {
+	info, err := GetSamplerInfo(sampler, C.CL_SAMPLER_REFERENCE_COUNT)
+	if err != nil {
+		return 0, err
+	}
+
+	refCount := *(*C.cl_uint)(unsafe.Pointer(&info[0]))
+	return UInt(refCount), nil
+}
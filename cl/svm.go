@@ -0,0 +1,404 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// 共享虚拟内存（SVM）
+//
+// SVM 分配的内存在主机和设备上使用相同的地址，内核可以直接解引用其中保存的指针，
+// 因此可以把树、链表等基于指针的数据结构原样交给内核。粗粒度 SVM 在主机访问前后需要
+// EnqueueSVMMap / EnqueueSVMUnmap；细粒度 SVM 可直接访问。设备支持的粒度可通过
+// GetDeviceSVMCapabilities 查询，设备不支持 SVM 时 SVMAlloc、EnqueueSVM*、SetKernelArgSVMPointer 和
+// SetKernelExecInfo* 返回包装了 ErrNotSupported 的错误。
+
+// ErrSVMAllocFailed SVM 分配失败（设备不支持 SVM、标志组合无效或内存不足）
+var ErrSVMAllocFailed = errors.New("cl: SVM allocation failed")
+
+// SVMAlloc 分配共享虚拟内存
+// 参数:
+//   - context: 上下文
+//   - flags: MemReadWrite 等内存标志，可组合 MemSVMFineGrainBuffer、MemSVMAtomics
+//   - size: 字节数
+//   - alignment: 对齐字节数，为 0 时使用默认对齐
+//
+// 返回:
+//   - unsafe.Pointer: 主机与设备共享的地址，需用 SVMFree 释放
+//   - error: 错误信息
+func SVMAlloc(context Context, flags UInt, size Size, alignment UInt) (unsafe.Pointer, error) {
+	if size == 0 {
+		return nil, OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+	devices, err := GetContextDevices(context)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireSVM(devices, "clSVMAlloc"); err != nil {
+		return nil, err
+	}
+
+	ptr := C.clSVMAlloc(
+		C.cl_context(context),
+		C.cl_svm_mem_flags(flags),
+		C.size_t(size),
+		C.cl_uint(alignment),
+	)
+	if ptr == nil {
+		return nil, ErrSVMAllocFailed
+	}
+
+	return ptr, nil
+}
+
+// SVMFree 释放 SVMAlloc 分配的内存
+// 调用前需确保已入队的命令不再使用该内存，例如先调用 Finish。
+func SVMFree(context Context, ptr unsafe.Pointer) {
+	C.clSVMFree(C.cl_context(context), ptr)
+}
+
+// EnqueueSVMMap 映射粗粒度 SVM 区域供主机访问
+func EnqueueSVMMap(queue CommandQueue, blocking Bool, mapFlags UInt, ptr unsafe.Pointer, size Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if err := requireQueueSVM(queue, "clEnqueueSVMMap"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	err = C.clEnqueueSVMMap(
+		C.cl_command_queue(queue),
+		C.cl_bool(blocking),
+		C.cl_map_flags(mapFlags),
+		ptr,
+		C.size_t(size),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueSVMUnmap 解除 EnqueueSVMMap 建立的映射，之后设备才能使用该区域
+func EnqueueSVMUnmap(queue CommandQueue, ptr unsafe.Pointer, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if err := requireQueueSVM(queue, "clEnqueueSVMUnmap"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	err = C.clEnqueueSVMUnmap(
+		C.cl_command_queue(queue),
+		ptr,
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueSVMMemcpy 在 SVM 区域与主机内存（或两个 SVM 区域）之间复制 size 字节
+// dst 或 src 指向 Go 内存时必须使用阻塞复制，否则复制完成前该内存可能被移动或回收。
+func EnqueueSVMMemcpy(queue CommandQueue, blocking Bool, dst unsafe.Pointer, src unsafe.Pointer, size Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if err := requireQueueSVM(queue, "clEnqueueSVMMemcpy"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	err = C.clEnqueueSVMMemcpy(
+		C.cl_command_queue(queue),
+		C.cl_bool(blocking),
+		dst,
+		src,
+		C.size_t(size),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueSVMMemFill 用 pattern 重复填充 SVM 区域中的 size 字节
+// pattern 长度需为 1、2、4、8、16、32、64 或 128，size 需为其整数倍。
+func EnqueueSVMMemFill(queue CommandQueue, ptr unsafe.Pointer, pattern []byte, size Size, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if len(pattern) == 0 {
+		return Event(nil), OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+
+	if err := requireQueueSVM(queue, "clEnqueueSVMMemFill"); err != nil {
+		return Event(nil), err
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	err = C.clEnqueueSVMMemFill(
+		C.cl_command_queue(queue),
+		ptr,
+		unsafe.Pointer(&pattern[0]),
+		C.size_t(len(pattern)),
+		C.size_t(size),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// EnqueueSVMMigrateMem 把 SVM 区域迁移到命令队列所在的设备（或在 flags 含 MigrateHost 时迁移到主机）
+// sizes 为空时迁移 ptrs 所在的整个分配；否则与 ptrs 一一对应，0 同样表示整个分配。
+func EnqueueSVMMigrateMem(queue CommandQueue, ptrs []unsafe.Pointer, sizes []Size, flags UInt, eventWaitList []Event) (Event, error) {
+	var err C.cl_int
+	var event C.cl_event
+
+	if len(ptrs) == 0 || (len(sizes) > 0 && len(sizes) != len(ptrs)) {
+		return Event(nil), OpenCLError{Code: Int(C.CL_INVALID_VALUE)}
+	}
+
+	if err := requireQueueVersion(queue, 2, 1, "clEnqueueSVMMigrateMem"); err != nil {
+		return Event(nil), err
+	}
+	if err := requireQueueSVM(queue, "clEnqueueSVMMigrateMem"); err != nil {
+		return Event(nil), err
+	}
+
+	var sizesPtr *C.size_t
+	if len(sizes) > 0 {
+		sizeArray := make([]C.size_t, len(sizes))
+		for i, s := range sizes {
+			sizeArray[i] = C.size_t(s)
+		}
+		sizesPtr = &sizeArray[0]
+	}
+
+	var waitList *C.cl_event
+	var waitListSize C.cl_uint
+	if len(eventWaitList) > 0 {
+		waitListSize = C.cl_uint(len(eventWaitList))
+		waitListArray := make([]C.cl_event, len(eventWaitList))
+		for i, e := range eventWaitList {
+			waitListArray[i] = C.cl_event(e)
+		}
+		waitList = &waitListArray[0]
+	}
+
+	err = C.clEnqueueSVMMigrateMem(
+		C.cl_command_queue(queue),
+		C.cl_uint(len(ptrs)),
+		&ptrs[0],
+		sizesPtr,
+		C.cl_mem_migration_flags(flags),
+		waitListSize,
+		waitList,
+		&event,
+	)
+
+	if err != C.CL_SUCCESS {
+		return Event(nil), OpenCLError{Code: Int(err)}
+	}
+
+	return Event(event), nil
+}
+
+// SetKernelArgSVMPointer 把 SVM 指针设置为内核参数，ptr 可以指向分配内部的任意位置
+func SetKernelArgSVMPointer(kernel Kernel, argIndex UInt, ptr unsafe.Pointer) error {
+	if err := requireKernelSVM(kernel, "clSetKernelArgSVMPointer"); err != nil {
+		return err
+	}
+
+	err := C.clSetKernelArgSVMPointer(
+		C.cl_kernel(kernel),
+		C.cl_uint(argIndex),
+		ptr,
+	)
+
+	if err != C.CL_SUCCESS {
+		return OpenCLError{Code: Int(err)}
+	}
+
+	return nil
+}
+
+// SetKernelExecInfo 设置内核执行信息
+func SetKernelExecInfo(kernel Kernel, paramName UInt, paramValueSize Size, paramValue unsafe.Pointer) error {
+	if err := requireKernelSVM(kernel, "clSetKernelExecInfo"); err != nil {
+		return err
+	}
+
+	err := C.clSetKernelExecInfo(
+		C.cl_kernel(kernel),
+		C.cl_kernel_exec_info(paramName),
+		C.size_t(paramValueSize),
+		paramValue,
+	)
+
+	if err != C.CL_SUCCESS {
+		return OpenCLError{Code: Int(err)}
+	}
+
+	return nil
+}
+
+// SetKernelExecInfoSVMPointers 声明内核会通过参数以外的途径（如数据结构中保存的指针）间接访问的 SVM 分配
+func SetKernelExecInfoSVMPointers(kernel Kernel, ptrs []unsafe.Pointer) error {
+	if len(ptrs) == 0 {
+		return SetKernelExecInfo(kernel, KernelExecInfoSVMPtrs, 0, nil)
+	}
+	return SetKernelExecInfo(kernel, KernelExecInfoSVMPtrs,
+		Size(uintptr(len(ptrs))*unsafe.Sizeof(ptrs[0])), unsafe.Pointer(&ptrs[0]))
+}
+
+// SetKernelExecInfoSVMFineGrainSystem 声明内核可能访问任意系统分配的内存（需要设备支持 SVMFineGrainSystem）
+func SetKernelExecInfoSVMFineGrainSystem(kernel Kernel, enable bool) error {
+	value := C.cl_bool(C.CL_FALSE)
+	if enable {
+		value = C.CL_TRUE
+	}
+	return SetKernelExecInfo(kernel, KernelExecInfoSVMFineGrainSystem, Size(unsafe.Sizeof(value)), unsafe.Pointer(&value))
+}
+
+// GetDeviceSVMCapabilities 获取设备的 SVM 能力，结果为 SVMCoarseGrainBuffer 等标志的组合
+// OpenCL 2.0 之前的设备不支持该查询，返回 0。
+func GetDeviceSVMCapabilities(device DeviceID) (uint64, error) {
+	if caps, ok := deviceSVMCapabilities.Load(device); ok {
+		return caps.(uint64), nil
+	}
+
+	caps, err := GetDeviceInfoULong(device, DeviceSVMCapabilities)
+	if err != nil {
+		var clErr OpenCLError
+		if !errors.As(err, &clErr) || clErr.Code != C.CL_INVALID_VALUE {
+			return 0, err
+		}
+		caps = 0
+	}
+
+	deviceSVMCapabilities.Store(device, caps)
+	return caps, nil
+}
+
+// requireSVM 检查 devices 中至少有一个设备支持 SVM（OpenCL 2.0 引入，3.0 中为可选功能）
+func requireSVM(devices []DeviceID, feature string) error {
+	for _, device := range devices {
+		caps, err := GetDeviceSVMCapabilities(device)
+		if err != nil {
+			return err
+		}
+		if caps != 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s requires a device with SVM support", ErrNotSupported, feature)
+}
+
+// requireKernelSVM 检查内核所在上下文中至少有一个设备支持 SVM
+func requireKernelSVM(kernel Kernel, feature string) error {
+	devices, err := kernelContextDevices(kernel)
+	if err != nil {
+		return err
+	}
+	return requireSVM(devices, feature)
+}
+
+// kernelContextDevices 通过 CL_KERNEL_CONTEXT 获取内核所在上下文的设备，结果会被缓存
+func kernelContextDevices(kernel Kernel) ([]DeviceID, error) {
+	if devices, ok := kernelDevices.Load(kernel); ok {
+		return devices.([]DeviceID), nil
+	}
+	context, err := GetKernelContext(kernel)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := GetContextDevices(context)
+	if err != nil {
+		return nil, err
+	}
+	kernelDevices.Store(kernel, devices)
+	return devices, nil
+}
+
+// requireQueueSVM 检查命令队列所在设备支持 SVM
+func requireQueueSVM(queue CommandQueue, feature string) error {
+	device, err := commandQueueDevice(queue)
+	if err != nil {
+		return err
+	}
+	return requireSVM([]DeviceID{device}, feature)
+}
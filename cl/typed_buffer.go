@@ -0,0 +1,303 @@
+package cl
+
+/*
+#cgo CFLAGS: -DCL_TARGET_OPENCL_VERSION=300
+#cgo windows LDFLAGS: -lOpenCL
+#cgo darwin LDFLAGS: -framework OpenCL
+#cgo linux pkg-config: OpenCL
+#include <CL/cl.h>
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"unsafe"
+)
+
+// ErrOutOfBounds 读写范围超出缓冲区
+var ErrOutOfBounds = errors.New("cl: buffer access out of bounds")
+
+// ErrInvalidElementType 元素类型无法直接在主机与设备之间按字节复制
+var ErrInvalidElementType = errors.New("cl: invalid buffer element type")
+
+// Buffer 带元素类型的缓冲区，记录元素类型与长度并对读写做边界检查
+// T 必须是不含指针的定长数据类型，例如 float32、int32、[4]float32 或由它们组成的结构体。
+type Buffer[T any] struct {
+	mem    MemObject
+	length int
+}
+
+// NewBuffer 创建可容纳 length 个元素的缓冲区
+func NewBuffer[T any](context Context, flags UInt, length int) (*Buffer[T], error) {
+	elemSize, err := elementSize[T]()
+	if err != nil {
+		return nil, err
+	}
+	if length <= 0 {
+		return nil, fmt.Errorf("cl: NewBuffer length %d: %w", length, OpenCLError{Code: Int(C.CL_INVALID_BUFFER_SIZE)})
+	}
+
+	mem, err := CreateBuffer(context, flags, Size(length*elemSize), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Buffer[T]{mem: mem, length: length}, nil
+}
+
+// NewBufferFromSlice 创建缓冲区并以 data 的内容初始化
+func NewBufferFromSlice[T any](context Context, flags UInt, data []T) (*Buffer[T], error) {
+	elemSize, err := elementSize[T]()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("cl: NewBufferFromSlice with empty slice: %w", OpenCLError{Code: Int(C.CL_INVALID_BUFFER_SIZE)})
+	}
+
+	mem, err := CreateBuffer(context, flags|MemCopyHostPtr, Size(len(data)*elemSize), unsafe.Pointer(&data[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Buffer[T]{mem: mem, length: len(data)}, nil
+}
+
+// MemObject 返回底层内存对象，可用于 SetKernelArg 等接口
+func (b *Buffer[T]) MemObject() MemObject {
+	return b.mem
+}
+
+// Len 返回元素个数
+func (b *Buffer[T]) Len() int {
+	return b.length
+}
+
+// Size 返回缓冲区字节数
+func (b *Buffer[T]) Size() Size {
+	return Size(b.length) * Size(unsafe.Sizeof(*new(T)))
+}
+
+// Write 把 data 写入缓冲区起始位置，阻塞直到写入完成
+func (b *Buffer[T]) Write(queue CommandQueue, data []T) error {
+	return b.WriteAt(queue, data, 0)
+}
+
+// WriteAt 把 data 写入从第 offset 个元素开始的位置，阻塞直到写入完成
+func (b *Buffer[T]) WriteAt(queue CommandQueue, data []T, offset int) error {
+	if len(data) == 0 {
+		return nil
+	}
+	if err := b.checkRange(offset, len(data)); err != nil {
+		return err
+	}
+
+	elemSize := unsafe.Sizeof(*new(T))
+	event, err := EnqueueWriteBuffer(queue, b.mem, Bool(C.CL_TRUE), Size(offset)*Size(elemSize),
+		Size(len(data))*Size(elemSize), unsafe.Pointer(&data[0]), nil)
+	if err != nil {
+		return err
+	}
+	return ReleaseEvent(event)
+}
+
+// Read 从缓冲区起始位置读取 len(dst) 个元素，阻塞直到读取完成
+func (b *Buffer[T]) Read(queue CommandQueue, dst []T) error {
+	return b.ReadAt(queue, dst, 0)
+}
+
+// ReadAt 从第 offset 个元素开始读取 len(dst) 个元素，阻塞直到读取完成
+func (b *Buffer[T]) ReadAt(queue CommandQueue, dst []T, offset int) error {
+	if len(dst) == 0 {
+		return nil
+	}
+	if err := b.checkRange(offset, len(dst)); err != nil {
+		return err
+	}
+
+	elemSize := unsafe.Sizeof(*new(T))
+	event, err := EnqueueReadBuffer(queue, b.mem, Bool(C.CL_TRUE), Size(offset)*Size(elemSize),
+		Size(len(dst))*Size(elemSize), unsafe.Pointer(&dst[0]), nil)
+	if err != nil {
+		return err
+	}
+	return ReleaseEvent(event)
+}
+
+// ReadRect 把缓冲区视为每行 rowLength 个元素的矩阵，读取左上角为 (x, y)、
+// 大小为 width×height 的子矩阵到 dst，dst 按 width 紧密排列，阻塞直到读取完成
+func (b *Buffer[T]) ReadRect(queue CommandQueue, rowLength, x, y, width, height int, dst []T) error {
+	if err := b.checkRect(rowLength, x, y, width, height, len(dst)); err != nil {
+		return err
+	}
+
+	elemSize := Size(unsafe.Sizeof(*new(T)))
+	event, err := EnqueueReadBufferRect(queue, b.mem, Bool(C.CL_TRUE),
+		[3]Size{Size(x) * elemSize, Size(y), 0},
+		[3]Size{0, 0, 0},
+		[3]Size{Size(width) * elemSize, Size(height), 1},
+		Size(rowLength)*elemSize, 0,
+		Size(width)*elemSize, 0,
+		unsafe.Pointer(&dst[0]), nil)
+	if err != nil {
+		return err
+	}
+	return ReleaseEvent(event)
+}
+
+// WriteRect 把紧密排列的 width×height 子矩阵 src 写入缓冲区中左上角为 (x, y) 的位置，
+// 缓冲区视为每行 rowLength 个元素的矩阵，阻塞直到写入完成
+func (b *Buffer[T]) WriteRect(queue CommandQueue, rowLength, x, y, width, height int, src []T) error {
+	if err := b.checkRect(rowLength, x, y, width, height, len(src)); err != nil {
+		return err
+	}
+
+	elemSize := Size(unsafe.Sizeof(*new(T)))
+	event, err := EnqueueWriteBufferRect(queue, b.mem, Bool(C.CL_TRUE),
+		[3]Size{Size(x) * elemSize, Size(y), 0},
+		[3]Size{0, 0, 0},
+		[3]Size{Size(width) * elemSize, Size(height), 1},
+		Size(rowLength)*elemSize, 0,
+		Size(width)*elemSize, 0,
+		unsafe.Pointer(&src[0]), nil)
+	if err != nil {
+		return err
+	}
+	return ReleaseEvent(event)
+}
+
+// Fill 用 value 填充整个缓冲区，等待填充完成后返回
+// T 的大小需为 1、2、4、8、16、32、64 或 128 字节，否则返回包装了 ErrInvalidElementType 的错误。
+func (b *Buffer[T]) Fill(queue CommandQueue, value T) error {
+	event, err := FillBuffer(queue, b.mem, value, 0, b.Size(), nil)
+	if err != nil {
+		return err
+	}
+	defer ReleaseEvent(event)
+
+	return WaitForEvents([]Event{event})
+}
+
+// CopyTo 把整个缓冲区复制到 dst 的起始位置，等待复制完成后返回
+func (b *Buffer[T]) CopyTo(queue CommandQueue, dst *Buffer[T]) error {
+	if dst.length < b.length {
+		return fmt.Errorf("%w: copy %d elements into buffer of length %d", ErrOutOfBounds, b.length, dst.length)
+	}
+
+	event, err := EnqueueCopyBuffer(queue, b.mem, dst.mem, 0, 0, b.Size(), nil)
+	if err != nil {
+		return err
+	}
+	defer ReleaseEvent(event)
+
+	return WaitForEvents([]Event{event})
+}
+
+// Slice 基于 CreateSubBuffer 返回元素区间 [start, end) 的子缓冲区
+// 子缓冲区与原缓冲区共享存储，需单独 Release；start 对应的字节偏移需满足设备的基地址对齐要求。
+func (b *Buffer[T]) Slice(start, end int) (*Buffer[T], error) {
+	if start < 0 || end <= start || end > b.length {
+		return nil, fmt.Errorf("%w: slice [%d:%d] of buffer with length %d", ErrOutOfBounds, start, end, b.length)
+	}
+
+	flags, err := GetMemObjectFlags(b.mem)
+	if err != nil {
+		return nil, err
+	}
+	// 子缓冲区继承父缓冲区的主机指针相关标志，创建时不能再次指定
+	flags &^= MemUseHostPtr | MemAllocHostPtr | MemCopyHostPtr
+
+	elemSize := Size(unsafe.Sizeof(*new(T)))
+	mem, err := CreateSubBufferRegion(b.mem, flags, BufferRegion{
+		Origin: Size(start) * elemSize,
+		Size:   Size(end-start) * elemSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Buffer[T]{mem: mem, length: end - start}, nil
+}
+
+// Release 释放缓冲区
+func (b *Buffer[T]) Release() error {
+	return ReleaseMemObject(b.mem)
+}
+
+// checkRange 检查 [offset, offset+count) 是否位于缓冲区内
+func (b *Buffer[T]) checkRange(offset, count int) error {
+	if offset < 0 || count > b.length-offset {
+		return fmt.Errorf("%w: offset %d, count %d, buffer length %d", ErrOutOfBounds, offset, count, b.length)
+	}
+	return nil
+}
+
+// checkRect 检查子矩阵是否位于缓冲区内，且主机切片能容纳 width×height 个元素
+func (b *Buffer[T]) checkRect(rowLength, x, y, width, height, hostLen int) error {
+	if rowLength <= 0 || x < 0 || y < 0 || width <= 0 || height <= 0 {
+		return fmt.Errorf("%w: rect (%d, %d) %dx%d with row length %d", ErrOutOfBounds, x, y, width, height, rowLength)
+	}
+	if width > rowLength-x || height > b.length/rowLength-y {
+		return fmt.Errorf("%w: rect (%d, %d) %dx%d exceeds %dx%d matrix", ErrOutOfBounds, x, y, width, height, rowLength, b.length/rowLength)
+	}
+	if hostLen < width*height {
+		return fmt.Errorf("%w: host slice length %d, rect needs %d", ErrOutOfBounds, hostLen, width*height)
+	}
+	return nil
+}
+
+// elementSize 返回 T 的字节大小，并检查 T 能否按字节传给设备
+func elementSize[T any]() (int, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if !isPlainDataType(t) {
+		return 0, fmt.Errorf("%w: %s contains pointers or non-fixed-size fields", ErrInvalidElementType, t)
+	}
+	if t.Size() == 0 {
+		return 0, fmt.Errorf("%w: %s has zero size", ErrInvalidElementType, t)
+	}
+	return int(t.Size()), nil
+}
+
+// fillPatternSize 返回 T 作为填充图案时的字节数
+// clEnqueueFillBuffer 只接受 1、2、4、8、16、32、64 或 128 字节的图案，例如 12 字节的结构体不能直接使用。
+func fillPatternSize[T any]() (int, error) {
+	size, err := elementSize[T]()
+	if err != nil {
+		return 0, err
+	}
+	if !isFillPatternSize(size) {
+		return 0, fmt.Errorf("%w: %s is %d bytes, fill patterns must be 1, 2, 4, 8, 16, 32, 64 or 128 bytes",
+			ErrInvalidElementType, reflect.TypeOf((*T)(nil)).Elem(), size)
+	}
+	return size, nil
+}
+
+// isFillPatternSize 判断 n 是否为 clEnqueueFillBuffer 接受的图案大小
+func isFillPatternSize(n int) bool {
+	return n > 0 && n <= 128 && n&(n-1) == 0
+}
+
+// isPlainDataType 判断类型是否为不含指针的定长数据
+func isPlainDataType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Bool,
+		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return true
+	case reflect.Array:
+		return isPlainDataType(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !isPlainDataType(t.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
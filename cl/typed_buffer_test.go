@@ -0,0 +1,42 @@
+package cl
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFillPatternSize(t *testing.T) {
+	type rgb struct{ R, G, B float32 }
+	type rgba struct{ R, G, B, A float32 }
+
+	if _, err := fillPatternSize[rgb](); !errors.Is(err, ErrInvalidElementType) {
+		t.Errorf("fillPatternSize[12-byte struct] = %v, want ErrInvalidElementType", err)
+	}
+	if _, err := fillPatternSize[[3]float64](); !errors.Is(err, ErrInvalidElementType) {
+		t.Errorf("fillPatternSize[24-byte array] = %v, want ErrInvalidElementType", err)
+	}
+	if _, err := fillPatternSize[[32]float64](); !errors.Is(err, ErrInvalidElementType) {
+		t.Errorf("fillPatternSize[256-byte array] = %v, want ErrInvalidElementType", err)
+	}
+	if size, err := fillPatternSize[rgba](); err != nil || size != 16 {
+		t.Errorf("fillPatternSize[16-byte struct] = %d, %v, want 16", size, err)
+	}
+	if size, err := fillPatternSize[Float16](); err != nil || size != 64 {
+		t.Errorf("fillPatternSize[Float16] = %d, %v, want 64", size, err)
+	}
+}
+
+func TestFillRejectsInvalidPatternBeforeEnqueue(t *testing.T) {
+	type rgb struct{ R, G, B float32 }
+
+	// 在调用驱动之前返回，因此可以使用空句柄
+	if _, err := FillBuffer(nil, nil, rgb{}, 0, 12, nil); !errors.Is(err, ErrInvalidElementType) {
+		t.Errorf("FillBuffer with 12-byte value = %v, want ErrInvalidElementType", err)
+	}
+	for _, n := range []int{0, 3, 12, 24, 256} {
+		var clErr OpenCLError
+		if _, err := EnqueueFillBuffer(nil, nil, make([]byte, n), 0, 0, nil); !errors.As(err, &clErr) {
+			t.Errorf("EnqueueFillBuffer with %d-byte pattern = %v, want OpenCLError", n, err)
+		}
+	}
+}
@@ -9,19 +9,27 @@ package cl
 #include <stdlib.h>
 */
 import "C"
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
 
 // 平台信息结构
 type PlatformInfo struct {
-	ID         PlatformID
-	Name       string
-	Vendor     string
-	Version    string
-	Profile    string
-	Extensions string
+	ID            PlatformID
+	Name          string
+	Vendor        string
+	Version       string
+	OpenCLVersion Version // 由 Version 解析得到
+	Profile       string
+	Extensions    string
+	ExtensionSet  ExtensionSet // 由 Extensions 解析得到
 }
 
 // 设备信息结构
+// 除名称、厂商、版本、类型、最大分配和最大工作组外，其余查询在设备不支持（如较新版本或扩展才有的信息）
+// 时不会导致 GetDeviceDetails 失败：对应字段保持零值，参数记录在 Absent 中，可通过 Has 判断。
 type DeviceInfo struct {
 	ID           DeviceID
 	Name         string
@@ -30,6 +38,178 @@ type DeviceInfo struct {
 	Type         uint64
 	MaxMemAlloc  uint64
 	MaxWorkGroup Size
+
+	// 版本与功能
+	OpenCLVersion                  Version // 由 Version 解析得到，无法解析时为零值且 Absent 中记录 DeviceVersion
+	Platform                       PlatformID
+	VendorID                       UInt
+	Profile                        string
+	DriverVersion                  string
+	NumericVersion                 UInt          // OpenCL 3.0
+	LatestConformanceVersionPassed string        // OpenCL 3.0，如 "v2021-02-01-00"
+	OpenCLCVersion                 string        // 如 "OpenCL C 1.2 "
+	OpenCLCAllVersions             []NameVersion // OpenCL 3.0
+	OpenCLCFeatures                []NameVersion // OpenCL 3.0，如 __opencl_c_fp64
+	Extensions                     ExtensionSet
+	ExtensionsWithVersion          []NameVersion // OpenCL 3.0
+	ILVersion                      string        // OpenCL 2.1
+	ILsWithVersion                 []NameVersion // OpenCL 3.0
+	BuiltInKernels                 string
+	BuiltInKernelsWithVersion      []NameVersion // OpenCL 3.0
+
+	Available              bool
+	CompilerAvailable      bool
+	LinkerAvailable        bool
+	EndianLittle           bool
+	ErrorCorrectionSupport bool
+	AddressBits            UInt
+
+	// 计算单元与工作项
+	MaxComputeUnits          UInt
+	MaxClockFrequency        UInt // MHz
+	MaxWorkItemDimensions    UInt
+	MaxWorkItemSizes         []Size
+	MaxParameterSize         Size
+	ProfilingTimerResolution Size // 纳秒
+	PrintfBufferSize         Size
+
+	PreferredWorkGroupSizeMultiple      Size // OpenCL 3.0
+	NonUniformWorkGroupSupport          bool // OpenCL 3.0
+	WorkGroupCollectiveFunctionsSupport bool // OpenCL 3.0
+	GenericAddressSpaceSupport          bool // OpenCL 3.0
+	PipeSupport                         bool // OpenCL 3.0
+
+	// 命令队列
+	QueueOnHostProperties     uint64 // QueueOutOfOrderExecModeEnable、QueueProfilingEnable 的组合
+	QueueOnDeviceProperties   uint64 // OpenCL 2.0
+	DeviceEnqueueCapabilities uint64 // OpenCL 3.0，DeviceQueueSupported、DeviceQueueReplaceableDefault 的组合
+
+	// 存储
+	GlobalMemSize          uint64
+	GlobalMemCacheType     UInt // GlobalMemCacheNone、GlobalMemCacheReadOnly 或 GlobalMemCacheReadWrite
+	GlobalMemCacheSize     uint64
+	GlobalMemCachelineSize UInt
+	LocalMemType           UInt // LocalMemTypeLocal 或 LocalMemTypeGlobal
+	LocalMemSize           uint64
+	MaxConstantBufferSize  uint64
+	MaxConstantArgs        UInt
+	MemBaseAddrAlign       UInt // 位
+
+	// 图像
+	ImageSupport              bool
+	MaxReadImageArgs          UInt
+	MaxWriteImageArgs         UInt
+	MaxReadWriteImageArgs     UInt // OpenCL 2.0
+	MaxSamplers               UInt
+	Image2DMaxWidth           Size
+	Image2DMaxHeight          Size
+	Image3DMaxWidth           Size
+	Image3DMaxHeight          Size
+	Image3DMaxDepth           Size
+	ImageMaxBufferSize        Size
+	ImageMaxArraySize         Size
+	ImagePitchAlignment       UInt // OpenCL 2.0，像素
+	ImageBaseAddressAlignment UInt // OpenCL 2.0，像素
+
+	// 向量宽度
+	PreferredVectorWidth VectorWidths
+	NativeVectorWidth    VectorWidths
+
+	// 浮点能力，FP* 标志的组合
+	HalfFPConfig   uint64 // cl_khr_fp16
+	SingleFPConfig uint64
+	DoubleFPConfig uint64 // cl_khr_fp64
+
+	// SVM、原子操作与子组
+	SVMCapabilities                    uint64 // OpenCL 2.0，SVM* 标志的组合
+	AtomicMemoryCapabilities           uint64 // OpenCL 3.0，Atomic* 标志的组合
+	AtomicFenceCapabilities            uint64 // OpenCL 3.0，Atomic* 标志的组合
+	MaxNumSubGroups                    UInt   // OpenCL 2.1
+	SubGroupIndependentForwardProgress bool   // OpenCL 2.1
+
+	// 设备划分
+	ParentDevice           DeviceID
+	PartitionMaxSubDevices UInt
+
+	// Absent 记录设备不支持或查询失败的参数及对应错误
+	Absent map[UInt]error
+}
+
+// Has 判断参数是否查询成功
+func (d *DeviceInfo) Has(paramName UInt) bool {
+	_, absent := d.Absent[paramName]
+	return !absent
+}
+
+// VectorWidths 各标量类型的向量宽度，设备不支持该类型时为 0
+type VectorWidths struct {
+	Char   UInt
+	Short  UInt
+	Int    UInt
+	Long   UInt
+	Float  UInt
+	Double UInt
+	Half   UInt
+}
+
+// ExtensionSet 扩展名集合
+type ExtensionSet map[string]struct{}
+
+// NewExtensionSet 解析以空格分隔的扩展名列表
+func NewExtensionSet(extensions string) ExtensionSet {
+	set := make(ExtensionSet)
+	for _, name := range strings.Fields(extensions) {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+// Has 判断是否包含指定扩展
+func (s ExtensionSet) Has(name string) bool {
+	_, ok := s[name]
+	return ok
+}
+
+// Names 返回按字母排序的扩展名
+func (s ExtensionSet) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NameVersion 带版本号的名称，对应 cl_name_version
+type NameVersion struct {
+	Name    string
+	Version UInt // 按 CL_MAKE_VERSION 编码的版本号
+}
+
+// Major 返回主版本号
+func (nv NameVersion) Major() UInt {
+	return nv.Version >> (C.CL_VERSION_MINOR_BITS + C.CL_VERSION_PATCH_BITS)
+}
+
+// Minor 返回次版本号
+func (nv NameVersion) Minor() UInt {
+	return nv.Version >> C.CL_VERSION_PATCH_BITS & (1<<C.CL_VERSION_MINOR_BITS - 1)
+}
+
+// Patch 返回补丁版本号
+func (nv NameVersion) Patch() UInt {
+	return nv.Version & (1<<C.CL_VERSION_PATCH_BITS - 1)
+}
+
+// String 返回 "名称 主.次.补丁" 形式的字符串
+func (nv NameVersion) String() string {
+	return fmt.Sprintf("%s %d.%d.%d", nv.Name, nv.Major(), nv.Minor(), nv.Patch())
+}
+
+// InfoElement 可由 GetDeviceInfoSlice、GetPlatformInfoSlice 读取的数组元素类型，
+// 如 Size（CL_DEVICE_MAX_WORK_ITEM_SIZES）、UInt 或 cl_ulong 对应的 uint64
+type InfoElement interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~int64 | ~uint64 | ~uintptr | ~float32 | ~float64
 }
 
 // 图像格式结构
@@ -38,6 +218,13 @@ type ImageFormat struct {
 	ChannelType  UInt
 }
 
+// 图像完整信息结构
+type ImageInfo struct {
+	Format      ImageFormat
+	Desc        ImageDesc
+	ElementSize Size // 每个像素的字节数
+}
+
 // 图像描述符结构
 type ImageDesc struct {
 	ImageType    UInt
@@ -52,6 +239,12 @@ type ImageDesc struct {
 	Buffer       MemObject
 }
 
+// 子缓冲区区域结构
+type BufferRegion struct {
+	Origin Size
+	Size   Size
+}
+
 type (
 	PlatformID   C.cl_platform_id
 	DeviceID     C.cl_device_id
@@ -61,6 +254,7 @@ type (
 	Kernel       C.cl_kernel
 	MemObject    C.cl_mem
 	Event        C.cl_event
+	Sampler      C.cl_sampler
 	Bool         C.cl_bool
 	UInt         C.cl_uint
 	Int          C.cl_int
@@ -82,16 +276,175 @@ const (
 	PlatformName       = C.CL_PLATFORM_NAME
 	PlatformVendor     = C.CL_PLATFORM_VENDOR
 	PlatformExtensions = C.CL_PLATFORM_EXTENSIONS
+
+	PlatformNumericVersion        = C.CL_PLATFORM_NUMERIC_VERSION
+	PlatformExtensionsWithVersion = C.CL_PLATFORM_EXTENSIONS_WITH_VERSION
+	PlatformHostTimerResolution   = C.CL_PLATFORM_HOST_TIMER_RESOLUTION
 )
 
 // 设备信息类型
 const (
-	DeviceName         = C.CL_DEVICE_NAME
-	DeviceVendor       = C.CL_DEVICE_VENDOR
-	DeviceVersion      = C.CL_DEVICE_VERSION
-	DeviceType         = C.CL_DEVICE_TYPE
-	DeviceMaxMemAlloc  = C.CL_DEVICE_MAX_MEM_ALLOC_SIZE
-	DeviceMaxWorkGroup = C.CL_DEVICE_MAX_WORK_GROUP_SIZE
+	DeviceName                    = C.CL_DEVICE_NAME
+	DeviceVendor                  = C.CL_DEVICE_VENDOR
+	DeviceVersion                 = C.CL_DEVICE_VERSION
+	DeviceType                    = C.CL_DEVICE_TYPE
+	DeviceMaxMemAlloc             = C.CL_DEVICE_MAX_MEM_ALLOC_SIZE
+	DeviceMaxWorkGroup            = C.CL_DEVICE_MAX_WORK_GROUP_SIZE
+	DeviceDriverVersion           = C.CL_DRIVER_VERSION
+	DeviceILVersion               = C.CL_DEVICE_IL_VERSION
+	DeviceILsWithVersion          = C.CL_DEVICE_ILS_WITH_VERSION
+	DeviceSVMCapabilities         = C.CL_DEVICE_SVM_CAPABILITIES
+	DeviceReferenceCount          = C.CL_DEVICE_REFERENCE_COUNT
+	DeviceParentDevice            = C.CL_DEVICE_PARENT_DEVICE
+	DevicePartitionMaxSubDevices  = C.CL_DEVICE_PARTITION_MAX_SUB_DEVICES
+	DevicePartitionProperties     = C.CL_DEVICE_PARTITION_PROPERTIES
+	DevicePartitionAffinityDomain = C.CL_DEVICE_PARTITION_AFFINITY_DOMAIN
+	DevicePartitionType           = C.CL_DEVICE_PARTITION_TYPE
+
+	DevicePlatform                            = C.CL_DEVICE_PLATFORM
+	DeviceProfile                             = C.CL_DEVICE_PROFILE
+	DeviceNumericVersion                      = C.CL_DEVICE_NUMERIC_VERSION
+	DeviceOpenCLCVersion                      = C.CL_DEVICE_OPENCL_C_VERSION
+	DeviceOpenCLCAllVersions                  = C.CL_DEVICE_OPENCL_C_ALL_VERSIONS
+	DeviceExtensions                          = C.CL_DEVICE_EXTENSIONS
+	DeviceBuiltInKernels                      = C.CL_DEVICE_BUILT_IN_KERNELS
+	DeviceExtensionsWithVersion               = C.CL_DEVICE_EXTENSIONS_WITH_VERSION
+	DeviceBuiltInKernelsWithVersion           = C.CL_DEVICE_BUILT_IN_KERNELS_WITH_VERSION
+	DeviceOpenCLCFeatures                     = C.CL_DEVICE_OPENCL_C_FEATURES
+	DeviceAvailable                           = C.CL_DEVICE_AVAILABLE
+	DeviceCompilerAvailable                   = C.CL_DEVICE_COMPILER_AVAILABLE
+	DeviceLinkerAvailable                     = C.CL_DEVICE_LINKER_AVAILABLE
+	DeviceEndianLittle                        = C.CL_DEVICE_ENDIAN_LITTLE
+	DeviceErrorCorrectionSupport              = C.CL_DEVICE_ERROR_CORRECTION_SUPPORT
+	DeviceAddressBits                         = C.CL_DEVICE_ADDRESS_BITS
+	DeviceMaxComputeUnits                     = C.CL_DEVICE_MAX_COMPUTE_UNITS
+	DeviceMaxClockFrequency                   = C.CL_DEVICE_MAX_CLOCK_FREQUENCY
+	DeviceMaxWorkItemDimensions               = C.CL_DEVICE_MAX_WORK_ITEM_DIMENSIONS
+	DeviceMaxWorkItemSizes                    = C.CL_DEVICE_MAX_WORK_ITEM_SIZES
+	DeviceMaxParameterSize                    = C.CL_DEVICE_MAX_PARAMETER_SIZE
+	DeviceProfilingTimerResolution            = C.CL_DEVICE_PROFILING_TIMER_RESOLUTION
+	DevicePrintfBufferSize                    = C.CL_DEVICE_PRINTF_BUFFER_SIZE
+	DeviceGlobalMemSize                       = C.CL_DEVICE_GLOBAL_MEM_SIZE
+	DeviceGlobalMemCacheType                  = C.CL_DEVICE_GLOBAL_MEM_CACHE_TYPE
+	DeviceGlobalMemCacheSize                  = C.CL_DEVICE_GLOBAL_MEM_CACHE_SIZE
+	DeviceGlobalMemCachelineSize              = C.CL_DEVICE_GLOBAL_MEM_CACHELINE_SIZE
+	DeviceLocalMemType                        = C.CL_DEVICE_LOCAL_MEM_TYPE
+	DeviceLocalMemSize                        = C.CL_DEVICE_LOCAL_MEM_SIZE
+	DeviceMaxConstantBufferSize               = C.CL_DEVICE_MAX_CONSTANT_BUFFER_SIZE
+	DeviceMaxConstantArgs                     = C.CL_DEVICE_MAX_CONSTANT_ARGS
+	DeviceMemBaseAddrAlign                    = C.CL_DEVICE_MEM_BASE_ADDR_ALIGN
+	DeviceImageSupport                        = C.CL_DEVICE_IMAGE_SUPPORT
+	DeviceMaxReadImageArgs                    = C.CL_DEVICE_MAX_READ_IMAGE_ARGS
+	DeviceMaxWriteImageArgs                   = C.CL_DEVICE_MAX_WRITE_IMAGE_ARGS
+	DeviceMaxReadWriteImageArgs               = C.CL_DEVICE_MAX_READ_WRITE_IMAGE_ARGS
+	DeviceMaxSamplers                         = C.CL_DEVICE_MAX_SAMPLERS
+	DeviceImage2DMaxWidth                     = C.CL_DEVICE_IMAGE2D_MAX_WIDTH
+	DeviceImage2DMaxHeight                    = C.CL_DEVICE_IMAGE2D_MAX_HEIGHT
+	DeviceImage3DMaxWidth                     = C.CL_DEVICE_IMAGE3D_MAX_WIDTH
+	DeviceImage3DMaxHeight                    = C.CL_DEVICE_IMAGE3D_MAX_HEIGHT
+	DeviceImage3DMaxDepth                     = C.CL_DEVICE_IMAGE3D_MAX_DEPTH
+	DeviceImageMaxBufferSize                  = C.CL_DEVICE_IMAGE_MAX_BUFFER_SIZE
+	DeviceImageMaxArraySize                   = C.CL_DEVICE_IMAGE_MAX_ARRAY_SIZE
+	DevicePreferredVectorWidthChar            = C.CL_DEVICE_PREFERRED_VECTOR_WIDTH_CHAR
+	DevicePreferredVectorWidthShort           = C.CL_DEVICE_PREFERRED_VECTOR_WIDTH_SHORT
+	DevicePreferredVectorWidthInt             = C.CL_DEVICE_PREFERRED_VECTOR_WIDTH_INT
+	DevicePreferredVectorWidthLong            = C.CL_DEVICE_PREFERRED_VECTOR_WIDTH_LONG
+	DevicePreferredVectorWidthFloat           = C.CL_DEVICE_PREFERRED_VECTOR_WIDTH_FLOAT
+	DevicePreferredVectorWidthDouble          = C.CL_DEVICE_PREFERRED_VECTOR_WIDTH_DOUBLE
+	DevicePreferredVectorWidthHalf            = C.CL_DEVICE_PREFERRED_VECTOR_WIDTH_HALF
+	DeviceNativeVectorWidthChar               = C.CL_DEVICE_NATIVE_VECTOR_WIDTH_CHAR
+	DeviceNativeVectorWidthShort              = C.CL_DEVICE_NATIVE_VECTOR_WIDTH_SHORT
+	DeviceNativeVectorWidthInt                = C.CL_DEVICE_NATIVE_VECTOR_WIDTH_INT
+	DeviceNativeVectorWidthLong               = C.CL_DEVICE_NATIVE_VECTOR_WIDTH_LONG
+	DeviceNativeVectorWidthFloat              = C.CL_DEVICE_NATIVE_VECTOR_WIDTH_FLOAT
+	DeviceNativeVectorWidthDouble             = C.CL_DEVICE_NATIVE_VECTOR_WIDTH_DOUBLE
+	DeviceNativeVectorWidthHalf               = C.CL_DEVICE_NATIVE_VECTOR_WIDTH_HALF
+	DeviceHalfFPConfig                        = 0x1033 // CL_DEVICE_HALF_FP_CONFIG，定义在 cl_ext.h 中
+	DeviceSingleFPConfig                      = C.CL_DEVICE_SINGLE_FP_CONFIG
+	DeviceDoubleFPConfig                      = C.CL_DEVICE_DOUBLE_FP_CONFIG
+	DeviceAtomicMemoryCapabilities            = C.CL_DEVICE_ATOMIC_MEMORY_CAPABILITIES
+	DeviceAtomicFenceCapabilities             = C.CL_DEVICE_ATOMIC_FENCE_CAPABILITIES
+	DeviceMaxNumSubGroups                     = C.CL_DEVICE_MAX_NUM_SUB_GROUPS
+	DeviceSubGroupIndependentForwardProgress  = C.CL_DEVICE_SUB_GROUP_INDEPENDENT_FORWARD_PROGRESS
+	DeviceVendorID                            = C.CL_DEVICE_VENDOR_ID
+	DeviceLatestConformanceVersionPassed      = C.CL_DEVICE_LATEST_CONFORMANCE_VERSION_PASSED
+	DevicePreferredWorkGroupSizeMultiple      = C.CL_DEVICE_PREFERRED_WORK_GROUP_SIZE_MULTIPLE
+	DeviceNonUniformWorkGroupSupport          = C.CL_DEVICE_NON_UNIFORM_WORK_GROUP_SUPPORT
+	DeviceWorkGroupCollectiveFunctionsSupport = C.CL_DEVICE_WORK_GROUP_COLLECTIVE_FUNCTIONS_SUPPORT
+	DeviceGenericAddressSpaceSupport          = C.CL_DEVICE_GENERIC_ADDRESS_SPACE_SUPPORT
+	DevicePipeSupport                         = C.CL_DEVICE_PIPE_SUPPORT
+	DeviceQueueOnHostProperties               = C.CL_DEVICE_QUEUE_ON_HOST_PROPERTIES
+	DeviceQueueOnDeviceProperties             = C.CL_DEVICE_QUEUE_ON_DEVICE_PROPERTIES
+	DeviceDeviceEnqueueCapabilities           = C.CL_DEVICE_DEVICE_ENQUEUE_CAPABILITIES
+	DeviceImagePitchAlignment                 = C.CL_DEVICE_IMAGE_PITCH_ALIGNMENT
+	DeviceImageBaseAddressAlignment           = C.CL_DEVICE_IMAGE_BASE_ADDRESS_ALIGNMENT
+)
+
+// 设备端入队能力
+const (
+	DeviceQueueSupported          = C.CL_DEVICE_QUEUE_SUPPORTED
+	DeviceQueueReplaceableDefault = C.CL_DEVICE_QUEUE_REPLACEABLE_DEFAULT
+)
+
+// 全局内存缓存类型
+const (
+	GlobalMemCacheNone      = C.CL_NONE
+	GlobalMemCacheReadOnly  = C.CL_READ_ONLY_CACHE
+	GlobalMemCacheReadWrite = C.CL_READ_WRITE_CACHE
+)
+
+// 局部内存类型
+const (
+	LocalMemTypeLocal  = C.CL_LOCAL
+	LocalMemTypeGlobal = C.CL_GLOBAL
+)
+
+// 浮点能力
+const (
+	FPDenorm                     = C.CL_FP_DENORM
+	FPInfNaN                     = C.CL_FP_INF_NAN
+	FPRoundToNearest             = C.CL_FP_ROUND_TO_NEAREST
+	FPRoundToZero                = C.CL_FP_ROUND_TO_ZERO
+	FPRoundToInf                 = C.CL_FP_ROUND_TO_INF
+	FPFMA                        = C.CL_FP_FMA
+	FPSoftFloat                  = C.CL_FP_SOFT_FLOAT
+	FPCorrectlyRoundedDivideSqrt = C.CL_FP_CORRECTLY_ROUNDED_DIVIDE_SQRT
+)
+
+// 原子操作能力
+const (
+	AtomicOrderRelaxed    = C.CL_DEVICE_ATOMIC_ORDER_RELAXED
+	AtomicOrderAcqRel     = C.CL_DEVICE_ATOMIC_ORDER_ACQ_REL
+	AtomicOrderSeqCst     = C.CL_DEVICE_ATOMIC_ORDER_SEQ_CST
+	AtomicScopeWorkItem   = C.CL_DEVICE_ATOMIC_SCOPE_WORK_ITEM
+	AtomicScopeWorkGroup  = C.CL_DEVICE_ATOMIC_SCOPE_WORK_GROUP
+	AtomicScopeDevice     = C.CL_DEVICE_ATOMIC_SCOPE_DEVICE
+	AtomicScopeAllDevices = C.CL_DEVICE_ATOMIC_SCOPE_ALL_DEVICES
+)
+
+// 设备划分方式
+const (
+	DevicePartitionEqually          = C.CL_DEVICE_PARTITION_EQUALLY
+	DevicePartitionByCounts         = C.CL_DEVICE_PARTITION_BY_COUNTS
+	DevicePartitionByAffinityDomain = C.CL_DEVICE_PARTITION_BY_AFFINITY_DOMAIN
+)
+
+// 设备亲和域
+const (
+	AffinityDomainNUMA              = C.CL_DEVICE_AFFINITY_DOMAIN_NUMA
+	AffinityDomainL4Cache           = C.CL_DEVICE_AFFINITY_DOMAIN_L4_CACHE
+	AffinityDomainL3Cache           = C.CL_DEVICE_AFFINITY_DOMAIN_L3_CACHE
+	AffinityDomainL2Cache           = C.CL_DEVICE_AFFINITY_DOMAIN_L2_CACHE
+	AffinityDomainL1Cache           = C.CL_DEVICE_AFFINITY_DOMAIN_L1_CACHE
+	AffinityDomainNextPartitionable = C.CL_DEVICE_AFFINITY_DOMAIN_NEXT_PARTITIONABLE
+)
+
+// 设备 SVM 能力
+const (
+	SVMCoarseGrainBuffer = C.CL_DEVICE_SVM_COARSE_GRAIN_BUFFER
+	SVMFineGrainBuffer   = C.CL_DEVICE_SVM_FINE_GRAIN_BUFFER
+	SVMFineGrainSystem   = C.CL_DEVICE_SVM_FINE_GRAIN_SYSTEM
+	SVMAtomics           = C.CL_DEVICE_SVM_ATOMICS
 )
 
 // 上下文属性
@@ -121,6 +474,10 @@ const (
 	MemHostWriteOnly = C.CL_MEM_HOST_WRITE_ONLY
 	MemHostReadOnly  = C.CL_MEM_HOST_READ_ONLY
 	MemHostNoAccess  = C.CL_MEM_HOST_NO_ACCESS
+
+	// SVMAlloc 专用标志
+	MemSVMFineGrainBuffer = C.CL_MEM_SVM_FINE_GRAIN_BUFFER
+	MemSVMAtomics         = C.CL_MEM_SVM_ATOMICS
 )
 
 // 图像通道顺序
@@ -156,11 +513,60 @@ const (
 	ChannelTypeFloat          = C.CL_FLOAT
 )
 
+// 子缓冲区创建类型
+const (
+	BufferCreateTypeRegion = C.CL_BUFFER_CREATE_TYPE_REGION
+)
+
 // 内存对象类型
 const (
-	MemObjectBuffer  = C.CL_MEM_OBJECT_BUFFER
-	MemObjectImage2D = C.CL_MEM_OBJECT_IMAGE2D
-	MemObjectImage3D = C.CL_MEM_OBJECT_IMAGE3D
+	MemObjectBuffer        = C.CL_MEM_OBJECT_BUFFER
+	MemObjectImage2D       = C.CL_MEM_OBJECT_IMAGE2D
+	MemObjectImage3D       = C.CL_MEM_OBJECT_IMAGE3D
+	MemObjectImage2DArray  = C.CL_MEM_OBJECT_IMAGE2D_ARRAY
+	MemObjectImage1D       = C.CL_MEM_OBJECT_IMAGE1D
+	MemObjectImage1DArray  = C.CL_MEM_OBJECT_IMAGE1D_ARRAY
+	MemObjectImage1DBuffer = C.CL_MEM_OBJECT_IMAGE1D_BUFFER
+	MemObjectPipe          = C.CL_MEM_OBJECT_PIPE
+)
+
+// 图像信息类型
+const (
+	ImageFormatInfo   = C.CL_IMAGE_FORMAT
+	ImageElementSize  = C.CL_IMAGE_ELEMENT_SIZE
+	ImageRowPitch     = C.CL_IMAGE_ROW_PITCH
+	ImageSlicePitch   = C.CL_IMAGE_SLICE_PITCH
+	ImageWidth        = C.CL_IMAGE_WIDTH
+	ImageHeight       = C.CL_IMAGE_HEIGHT
+	ImageDepth        = C.CL_IMAGE_DEPTH
+	ImageArraySize    = C.CL_IMAGE_ARRAY_SIZE
+	ImageBuffer       = C.CL_IMAGE_BUFFER
+	ImageNumMipLevels = C.CL_IMAGE_NUM_MIP_LEVELS
+	ImageNumSamples   = C.CL_IMAGE_NUM_SAMPLES
+)
+
+// 采样器寻址模式
+const (
+	AddressNone           = C.CL_ADDRESS_NONE
+	AddressClampToEdge    = C.CL_ADDRESS_CLAMP_TO_EDGE
+	AddressClamp          = C.CL_ADDRESS_CLAMP
+	AddressRepeat         = C.CL_ADDRESS_REPEAT
+	AddressMirroredRepeat = C.CL_ADDRESS_MIRRORED_REPEAT
+)
+
+// 采样器过滤模式
+const (
+	FilterNearest = C.CL_FILTER_NEAREST
+	FilterLinear  = C.CL_FILTER_LINEAR
+)
+
+// 采样器信息类型（同时用作 CreateSamplerWithProperties 的属性名）
+const (
+	SamplerReferenceCount   = C.CL_SAMPLER_REFERENCE_COUNT
+	SamplerContext          = C.CL_SAMPLER_CONTEXT
+	SamplerNormalizedCoords = C.CL_SAMPLER_NORMALIZED_COORDS
+	SamplerAddressingMode   = C.CL_SAMPLER_ADDRESSING_MODE
+	SamplerFilterMode       = C.CL_SAMPLER_FILTER_MODE
 )
 
 // 映射标志
@@ -170,12 +576,36 @@ const (
 	MapWriteInvalidateRegion = C.CL_MAP_WRITE_INVALIDATE_REGION
 )
 
+// 内存对象迁移标志
+const (
+	MigrateHost             = C.CL_MIGRATE_MEM_OBJECT_HOST
+	MigrateContentUndefined = C.CL_MIGRATE_MEM_OBJECT_CONTENT_UNDEFINED
+)
+
 // 程序构建状态
+// GetProgramBuildStatus 以 UInt 返回状态，与这些常量比较时需先转换为 Int。
+const (
+	BuildStatusSuccess    = C.CL_BUILD_SUCCESS
+	BuildStatusNone       = C.CL_BUILD_NONE
+	BuildStatusError      = C.CL_BUILD_ERROR
+	BuildStatusInProgress = C.CL_BUILD_IN_PROGRESS
+
+	// Deprecated: 使用 BuildStatusSuccess
+	BuildSuccess = BuildStatusSuccess
+	// Deprecated: 使用 BuildStatusNone
+	BuildNone = BuildStatusNone
+	// Deprecated: 使用 BuildStatusError
+	BuildError = BuildStatusError
+	// Deprecated: 使用 BuildStatusInProgress
+	BuildInProgress = BuildStatusInProgress
+)
+
+// 程序二进制类型
 const (
-	BuildSuccess    = C.CL_BUILD_SUCCESS
-	BuildNone       = C.CL_BUILD_NONE
-	BuildError      = C.CL_BUILD_ERROR
-	BuildInProgress = C.CL_BUILD_IN_PROGRESS
+	ProgramBinaryTypeNone           = C.CL_PROGRAM_BINARY_TYPE_NONE
+	ProgramBinaryTypeCompiledObject = C.CL_PROGRAM_BINARY_TYPE_COMPILED_OBJECT
+	ProgramBinaryTypeLibrary        = C.CL_PROGRAM_BINARY_TYPE_LIBRARY
+	ProgramBinaryTypeExecutable     = C.CL_PROGRAM_BINARY_TYPE_EXECUTABLE
 )
 
 // 程序信息类型
@@ -201,6 +631,46 @@ const (
 	KernelAttributes     = C.CL_KERNEL_ATTRIBUTES
 )
 
+// 内核执行信息类型
+const (
+	KernelExecInfoSVMPtrs            = C.CL_KERNEL_EXEC_INFO_SVM_PTRS
+	KernelExecInfoSVMFineGrainSystem = C.CL_KERNEL_EXEC_INFO_SVM_FINE_GRAIN_SYSTEM
+)
+
+// 内核参数信息类型
+const (
+	KernelArgAddressQualifier = C.CL_KERNEL_ARG_ADDRESS_QUALIFIER
+	KernelArgAccessQualifier  = C.CL_KERNEL_ARG_ACCESS_QUALIFIER
+	KernelArgTypeName         = C.CL_KERNEL_ARG_TYPE_NAME
+	KernelArgTypeQualifier    = C.CL_KERNEL_ARG_TYPE_QUALIFIER
+	KernelArgName             = C.CL_KERNEL_ARG_NAME
+)
+
+// 内核参数地址空间限定符
+const (
+	KernelArgAddressGlobal   = C.CL_KERNEL_ARG_ADDRESS_GLOBAL
+	KernelArgAddressLocal    = C.CL_KERNEL_ARG_ADDRESS_LOCAL
+	KernelArgAddressConstant = C.CL_KERNEL_ARG_ADDRESS_CONSTANT
+	KernelArgAddressPrivate  = C.CL_KERNEL_ARG_ADDRESS_PRIVATE
+)
+
+// 内核参数访问限定符
+const (
+	KernelArgAccessReadOnly  = C.CL_KERNEL_ARG_ACCESS_READ_ONLY
+	KernelArgAccessWriteOnly = C.CL_KERNEL_ARG_ACCESS_WRITE_ONLY
+	KernelArgAccessReadWrite = C.CL_KERNEL_ARG_ACCESS_READ_WRITE
+	KernelArgAccessNone      = C.CL_KERNEL_ARG_ACCESS_NONE
+)
+
+// 内核参数类型限定符（位标志）
+const (
+	KernelArgTypeNone     = C.CL_KERNEL_ARG_TYPE_NONE
+	KernelArgTypeConst    = C.CL_KERNEL_ARG_TYPE_CONST
+	KernelArgTypeRestrict = C.CL_KERNEL_ARG_TYPE_RESTRICT
+	KernelArgTypeVolatile = C.CL_KERNEL_ARG_TYPE_VOLATILE
+	KernelArgTypePipe     = C.CL_KERNEL_ARG_TYPE_PIPE
+)
+
 // 内核工作项信息类型
 const (
 	KernelWorkGroupSize                  = C.CL_KERNEL_WORK_GROUP_SIZE
@@ -219,6 +689,15 @@ const (
 	EventContext           = 0x11D4
 )
 
+// 事件性能分析信息类型
+const (
+	ProfilingCommandQueued   = C.CL_PROFILING_COMMAND_QUEUED
+	ProfilingCommandSubmit   = C.CL_PROFILING_COMMAND_SUBMIT
+	ProfilingCommandStart    = C.CL_PROFILING_COMMAND_START
+	ProfilingCommandEnd      = C.CL_PROFILING_COMMAND_END
+	ProfilingCommandComplete = C.CL_PROFILING_COMMAND_COMPLETE
+)
+
 // 命令类型 - 使用数值常量避免编译错误
 const (
 	CommandNDRangeKernel     = 0x11F0
@@ -244,13 +723,12 @@ const (
 	CommandUser              = 0x1204
 )
 
-// 命令执行状态 - 使用数值常量避免编译错误
+// 命令执行状态
 const (
-	CommandQueued    = 0x1205
-	CommandSubmitted = 0x1206
-	CommandRunning   = 0x1207
-	CommandComplete  = 0x1208
-	CommandError     = 0x1209
+	CommandQueued    = C.CL_QUEUED
+	CommandSubmitted = C.CL_SUBMITTED
+	CommandRunning   = C.CL_RUNNING
+	CommandComplete  = C.CL_COMPLETE
 )
 
 // OpenCLError 自定义错误类型
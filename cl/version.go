@@ -0,0 +1,247 @@
+package cl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNotSupported 设备或平台不支持所需的 OpenCL 版本、扩展或功能
+// 需要 OpenCL 1.1/1.2/2.0/2.1/3.0 功能的调用会在调用驱动前检查设备版本，不满足时返回包装了该错误的错误，
+// 可通过 errors.Is(err, cl.ErrNotSupported) 判断。
+var ErrNotSupported = errors.New("cl: not supported")
+
+// Version 解析后的 OpenCL 版本
+type Version struct {
+	Major  int
+	Minor  int
+	Vendor string // 版本号之后的厂商信息，如 "CUDA 12.4.131" 或 "PoCL 5.0+debian Linux, ..."
+}
+
+// versionPattern 匹配 "OpenCL 3.0 PoCL ..."（平台/设备版本）和 "OpenCL C 1.2 "（OpenCL C 版本）
+var versionPattern = regexp.MustCompile(`^OpenCL(?: C)?\s+(\d+)\.(\d+)\s*(.*)$`)
+
+// ParseVersion 解析 CL_PLATFORM_VERSION、CL_DEVICE_VERSION 或 CL_DEVICE_OPENCL_C_VERSION 返回的版本字符串
+func ParseVersion(s string) (Version, error) {
+	m := versionPattern.FindStringSubmatch(s)
+	if m == nil {
+		return Version{}, fmt.Errorf("cl: invalid OpenCL version %q", s)
+	}
+	major, _ := strconv.Atoi(m[1])
+	minor, _ := strconv.Atoi(m[2])
+	return Version{Major: major, Minor: minor, Vendor: strings.TrimSpace(m[3])}, nil
+}
+
+// Compare 比较两个版本号（忽略 Vendor），v 较小时返回 -1，相等返回 0，较大返回 1
+func (v Version) Compare(other Version) int {
+	switch {
+	case v.Major != other.Major:
+		if v.Major < other.Major {
+			return -1
+		}
+		return 1
+	case v.Minor != other.Minor:
+		if v.Minor < other.Minor {
+			return -1
+		}
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast 判断版本是否不低于 major.minor
+func (v Version) AtLeast(major, minor int) bool {
+	return v.Compare(Version{Major: major, Minor: minor}) >= 0
+}
+
+// String 返回 "OpenCL 主.次 厂商信息" 形式的字符串
+func (v Version) String() string {
+	if v.Vendor == "" {
+		return fmt.Sprintf("OpenCL %d.%d", v.Major, v.Minor)
+	}
+	return fmt.Sprintf("OpenCL %d.%d %s", v.Major, v.Minor, v.Vendor)
+}
+
+// deviceVersions 缓存设备版本，特性检查在每次调用时都会用到
+var deviceVersions sync.Map // DeviceID -> Version
+
+// deviceSVMCapabilities 缓存设备的 SVM 能力，每次 EnqueueSVM* 调用前都会检查
+var deviceSVMCapabilities sync.Map // DeviceID -> uint64
+
+// queueDevices 缓存命令队列所在的设备，供按队列检查的特性检查使用，ReleaseCommandQueue 时删除
+var queueDevices sync.Map // CommandQueue -> DeviceID
+
+// kernelDevices 缓存内核所在上下文的设备，供 SVM 内核参数的检查使用，ReleaseKernel 时删除
+var kernelDevices sync.Map // Kernel -> []DeviceID
+
+// GetPlatformVersion 获取并解析平台的 OpenCL 版本
+func GetPlatformVersion(platform PlatformID) (Version, error) {
+	version, err := GetPlatformInfo(platform, PlatformVersion)
+	if err != nil {
+		return Version{}, err
+	}
+	return ParseVersion(version)
+}
+
+// GetDeviceVersion 获取并解析设备的 OpenCL 版本
+func GetDeviceVersion(device DeviceID) (Version, error) {
+	if v, ok := deviceVersions.Load(device); ok {
+		return v.(Version), nil
+	}
+
+	version, err := GetDeviceInfo(device, DeviceVersion)
+	if err != nil {
+		return Version{}, err
+	}
+	v, err := ParseVersion(version)
+	if err != nil {
+		return Version{}, err
+	}
+
+	deviceVersions.Store(device, v)
+	return v, nil
+}
+
+// GetPlatformExtensions 获取平台支持的扩展
+func GetPlatformExtensions(platform PlatformID) (ExtensionSet, error) {
+	extensions, err := GetPlatformInfo(platform, PlatformExtensions)
+	if err != nil {
+		return nil, err
+	}
+	return NewExtensionSet(extensions), nil
+}
+
+// GetDeviceExtensions 获取设备支持的扩展
+func GetDeviceExtensions(device DeviceID) (ExtensionSet, error) {
+	extensions, err := GetDeviceInfo(device, DeviceExtensions)
+	if err != nil {
+		return nil, err
+	}
+	return NewExtensionSet(extensions), nil
+}
+
+// RequireDeviceVersion 检查设备版本不低于 major.minor，否则返回包装了 ErrNotSupported 的错误
+func RequireDeviceVersion(device DeviceID, major, minor int) error {
+	return requireDeviceVersion(device, major, minor, "")
+}
+
+// RequireDeviceExtension 检查设备支持指定扩展，否则返回包装了 ErrNotSupported 的错误
+func RequireDeviceExtension(device DeviceID, name string) error {
+	extensions, err := GetDeviceExtensions(device)
+	if err != nil {
+		return err
+	}
+	if !extensions.Has(name) {
+		return fmt.Errorf("%w: device does not support %s", ErrNotSupported, name)
+	}
+	return nil
+}
+
+// RequirePlatformVersion 检查平台版本不低于 major.minor，否则返回包装了 ErrNotSupported 的错误
+func RequirePlatformVersion(platform PlatformID, major, minor int) error {
+	v, err := GetPlatformVersion(platform)
+	if err != nil {
+		return err
+	}
+	if !v.AtLeast(major, minor) {
+		return fmt.Errorf("%w: OpenCL %d.%d required, platform reports %s", ErrNotSupported, major, minor, v)
+	}
+	return nil
+}
+
+// requireDeviceVersion 在调用需要较新版本的 API 前检查设备版本，feature 为错误信息中的功能名
+func requireDeviceVersion(device DeviceID, major, minor int, feature string) error {
+	v, err := GetDeviceVersion(device)
+	if err != nil {
+		return err
+	}
+	if v.AtLeast(major, minor) {
+		return nil
+	}
+	if feature == "" {
+		return fmt.Errorf("%w: OpenCL %d.%d required, device reports %s", ErrNotSupported, major, minor, v)
+	}
+	return fmt.Errorf("%w: %s requires OpenCL %d.%d, device reports %s", ErrNotSupported, feature, major, minor, v)
+}
+
+// requireDevicesVersion 检查 devices 中的每个设备
+func requireDevicesVersion(devices []DeviceID, major, minor int, feature string) error {
+	for _, device := range devices {
+		if err := requireDeviceVersion(device, major, minor, feature); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireQueueVersion 检查命令队列所在设备的版本
+func requireQueueVersion(queue CommandQueue, major, minor int, feature string) error {
+	device, err := commandQueueDevice(queue)
+	if err != nil {
+		return err
+	}
+	return requireDeviceVersion(device, major, minor, feature)
+}
+
+// commandQueueDevice 与 GetCommandQueueDevice 相同，但结果会被缓存
+func commandQueueDevice(queue CommandQueue) (DeviceID, error) {
+	if device, ok := queueDevices.Load(queue); ok {
+		return device.(DeviceID), nil
+	}
+	device, err := GetCommandQueueDevice(queue)
+	if err != nil {
+		return DeviceID(nil), err
+	}
+	queueDevices.Store(queue, device)
+	return device, nil
+}
+
+// requireContextVersion 检查上下文中至少有一个设备满足版本要求
+func requireContextVersion(context Context, major, minor int, feature string) error {
+	devices, err := GetContextDevices(context)
+	if err != nil {
+		return err
+	}
+	var firstErr error
+	for _, device := range devices {
+		err := requireDeviceVersion(device, major, minor, feature)
+		if err == nil {
+			return nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// requireContextIL 检查上下文中至少有一个 OpenCL 2.1 以上且 CL_DEVICE_IL_VERSION 非空的设备
+// （OpenCL 3.0 中 IL 是可选功能）。只提供 cl_khr_il_program 扩展的设备不算在内：
+// 该扩展只提供 clCreateProgramWithILKHR，而 CreateProgramWithIL 调用的是核心函数 clCreateProgramWithIL。
+func requireContextIL(context Context, feature string) error {
+	devices, err := GetContextDevices(context)
+	if err != nil {
+		return err
+	}
+	for _, device := range devices {
+		v, err := GetDeviceVersion(device)
+		if err != nil {
+			return err
+		}
+		if !v.AtLeast(2, 1) {
+			continue
+		}
+		ils, err := GetDeviceILs(device)
+		if err != nil {
+			return err
+		}
+		if len(ils) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s requires an OpenCL 2.1 device that reports CL_DEVICE_IL_VERSION", ErrNotSupported, feature)
+}
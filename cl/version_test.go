@@ -0,0 +1,112 @@
+package cl
+
+import "testing"
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Version
+		wantErr bool
+	}{
+		{in: "OpenCL 3.0 CUDA 12.4", want: Version{Major: 3, Minor: 0, Vendor: "CUDA 12.4"}},
+		{in: "OpenCL C 1.2 ", want: Version{Major: 1, Minor: 2}},
+		{in: "OpenCL 2.1 AMD-APP (3513.0)", want: Version{Major: 2, Minor: 1, Vendor: "AMD-APP (3513.0)"}},
+		{in: "OpenCL 3.0 PoCL 5.0+debian  Linux, None+Asserts", want: Version{Major: 3, Minor: 0, Vendor: "PoCL 5.0+debian  Linux, None+Asserts"}},
+		{in: "OpenCL 1.2", want: Version{Major: 1, Minor: 2}},
+		{in: "OpenCL C 3.0", want: Version{Major: 3, Minor: 0}},
+		{in: "OpenCL 10.12 future", want: Version{Major: 10, Minor: 12, Vendor: "future"}},
+		{in: "", wantErr: true},
+		{in: "OpenCL", wantErr: true},
+		{in: "OpenCL 3", wantErr: true},
+		{in: "OpenCL C", wantErr: true},
+		{in: "opencl 1.2", wantErr: true},
+		{in: " OpenCL 1.2", wantErr: true},
+		{in: "CUDA 12.4", wantErr: true},
+		{in: "OpenCL x.y", wantErr: true},
+		{in: "\x00\xff garbage", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseVersion(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseVersion(%q) = %+v, want error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error: %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b Version
+		want int
+	}{
+		{Version{Major: 1, Minor: 2}, Version{Major: 1, Minor: 2}, 0},
+		{Version{Major: 1, Minor: 2}, Version{Major: 2, Minor: 0}, -1},
+		{Version{Major: 2, Minor: 0}, Version{Major: 1, Minor: 2}, 1},
+		{Version{Major: 2, Minor: 0}, Version{Major: 2, Minor: 1}, -1},
+		{Version{Major: 2, Minor: 1}, Version{Major: 2, Minor: 0}, 1},
+		{Version{Major: 1, Minor: 10}, Version{Major: 2, Minor: 0}, -1},
+		{Version{Major: 3, Minor: 0, Vendor: "CUDA 12.4"}, Version{Major: 3, Minor: 0, Vendor: "PoCL"}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%v.Compare(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		v            Version
+		major, minor int
+		want         bool
+	}{
+		{Version{Major: 3, Minor: 0}, 2, 1, true},
+		{Version{Major: 3, Minor: 0}, 3, 0, true},
+		{Version{Major: 3, Minor: 0}, 3, 1, false},
+		{Version{Major: 2, Minor: 1}, 2, 1, true},
+		{Version{Major: 2, Minor: 0}, 2, 1, false},
+		{Version{Major: 1, Minor: 2}, 2, 0, false},
+		{Version{}, 1, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.v.AtLeast(tt.major, tt.minor); got != tt.want {
+			t.Errorf("%v.AtLeast(%d, %d) = %v, want %v", tt.v, tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	tests := []struct {
+		v    Version
+		want string
+	}{
+		{Version{Major: 1, Minor: 2}, "OpenCL 1.2"},
+		{Version{Major: 3, Minor: 0, Vendor: "CUDA 12.4"}, "OpenCL 3.0 CUDA 12.4"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.v.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+		if tt.v.Vendor != "" {
+			continue
+		}
+		// 无厂商信息的字符串可以原样解析回来
+		if parsed, err := ParseVersion(tt.v.String()); err != nil || parsed != tt.v {
+			t.Errorf("ParseVersion(%q) = %+v, %v, want %+v", tt.v.String(), parsed, err, tt.v)
+		}
+	}
+}
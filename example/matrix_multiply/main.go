@@ -123,7 +123,7 @@ func benchmarkCPU(size int) float64 {
 }
 
 func benchmarkGPU(ctx cl.Context, device cl.DeviceID, size int) (float64, error) {
-	queue, err := cl.CreateCommandQueue(ctx, device, 0)
+	queue, err := cl.CreateCommandQueue(ctx, device, cl.QueueProfilingEnable)
 	if err != nil {
 		return 0, err
 	}
@@ -212,13 +212,19 @@ __kernel void matrix_multiply(__global const float* a, __global const float* b,
 	cl.SetKernelArg(kernel, 2, cl.Size(unsafe.Sizeof(bufC)), unsafe.Pointer(&bufC))
 	cl.SetKernelArg(kernel, 3, cl.Size(4), unsafe.Pointer(&size))
 
-	// 执行内核
-	start := time.Now()
-	if err := cl.EnqueueNDRangeKernel(queue, kernel, cl.UInt(2), nil, []cl.Size{cl.Size(size), cl.Size(size)}, nil, nil, nil); err != nil {
+	// 执行内核，使用事件的设备时间戳计时
+	var kernelEvent cl.Event
+	if err := cl.EnqueueNDRangeKernel(queue, kernel, cl.UInt(2), nil, []cl.Size{cl.Size(size), cl.Size(size)}, nil, nil, &kernelEvent); err != nil {
 		return 0, err
 	}
+	defer cl.ReleaseEvent(kernelEvent)
 	cl.Finish(queue)
-	gpuTime := time.Since(start).Seconds()
+
+	profile, err := cl.GetEventProfilingInfo(kernelEvent)
+	if err != nil {
+		return 0, err
+	}
+	gpuTime := profile.ExecutionTime().Seconds()
 
 	// 读取结果
 	if _, err := cl.EnqueueReadBuffer(queue, bufC, cl.Bool(1), 0, bufSize, unsafe.Pointer(&c[0]), nil); err != nil {
@@ -0,0 +1,111 @@
+package ocl
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/suanju/go-opencl/cl"
+)
+
+// Context 上下文对象
+type Context struct {
+	obj     *object
+	handle  cl.Context
+	devices []cl.DeviceID
+}
+
+// NewContext 在指定平台上为设备列表创建上下文
+func NewContext(platform cl.PlatformID, devices []cl.DeviceID) (*Context, error) {
+	props := map[cl.UInt]interface{}{cl.ContextPlatform: platform}
+	handle, err := cl.CreateContext(platform, devices, props)
+	if err != nil {
+		return nil, err
+	}
+	return wrapContext(handle, devices), nil
+}
+
+// wrapContext 接管上下文句柄
+func wrapContext(handle cl.Context, devices []cl.DeviceID) *Context {
+	c := &Context{
+		obj:     newObject("Context", func() error { return cl.ReleaseContext(handle) }),
+		handle:  handle,
+		devices: append([]cl.DeviceID(nil), devices...),
+	}
+	track(c, c.obj)
+	return c
+}
+
+// Handle 返回原始上下文句柄
+func (c *Context) Handle() cl.Context {
+	return c.handle
+}
+
+// Devices 返回上下文中的设备，返回的切片是副本
+func (c *Context) Devices() []cl.DeviceID {
+	return append([]cl.DeviceID(nil), c.devices...)
+}
+
+// Close 释放上下文，可重复调用
+func (c *Context) Close() error {
+	return c.obj.close()
+}
+
+// CreateCommandQueue 为设备创建命令队列
+func (c *Context) CreateCommandQueue(device cl.DeviceID, properties cl.UInt) (*CommandQueue, error) {
+	unlock, err := acquire(c.obj)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.CreateCommandQueue(c.handle, device, properties)
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+	return wrapCommandQueue(handle), nil
+}
+
+// CreateBuffer 创建缓冲区
+func (c *Context) CreateBuffer(flags cl.UInt, size cl.Size, hostPtr unsafe.Pointer) (*MemObject, error) {
+	unlock, err := acquire(c.obj)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.CreateBuffer(c.handle, flags, size, hostPtr)
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+	return wrapMemObject(handle), nil
+}
+
+// CreateProgramWithSource 从源码创建程序
+func (c *Context) CreateProgramWithSource(sources ...string) (*Program, error) {
+	unlock, err := acquire(c.obj)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.CreateProgramWithSource(c.handle, cl.UInt(len(sources)), sources, nil)
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+	return wrapProgram(handle), nil
+}
+
+// CreateUserEvent 创建用户事件
+func (c *Context) CreateUserEvent() (*Event, error) {
+	unlock, err := acquire(c.obj)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.CreateUserEvent(c.handle)
+	runtime.KeepAlive(c)
+	if err != nil {
+		return nil, err
+	}
+	return wrapEvent(handle), nil
+}
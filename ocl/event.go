@@ -0,0 +1,83 @@
+package ocl
+
+import (
+	"runtime"
+
+	"github.com/suanju/go-opencl/cl"
+)
+
+// Event 事件对象
+type Event struct {
+	obj    *object
+	handle cl.Event
+}
+
+// wrapEvent 接管事件句柄
+func wrapEvent(handle cl.Event) *Event {
+	e := &Event{
+		obj:    newObject("Event", func() error { return cl.ReleaseEvent(handle) }),
+		handle: handle,
+	}
+	track(e, e.obj)
+	return e
+}
+
+// Handle 返回原始事件句柄
+func (e *Event) Handle() cl.Event {
+	return e.handle
+}
+
+// Wait 等待事件完成
+func (e *Event) Wait() error {
+	unlock, err := acquire(e.obj)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = cl.WaitForEvents([]cl.Event{e.handle})
+	runtime.KeepAlive(e)
+	return err
+}
+
+// Status 返回命令执行状态
+func (e *Event) Status() (cl.Int, error) {
+	unlock, err := acquire(e.obj)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	status, err := cl.GetEventCommandExecStatus(e.handle)
+	runtime.KeepAlive(e)
+	return status, err
+}
+
+// SetStatus 设置用户事件状态
+func (e *Event) SetStatus(status cl.Int) error {
+	unlock, err := acquire(e.obj)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = cl.SetUserEventStatus(e.handle, status)
+	runtime.KeepAlive(e)
+	return err
+}
+
+// Close 释放事件，可重复调用
+func (e *Event) Close() error {
+	return e.obj.close()
+}
+
+// eventHandles 返回事件的原始句柄及其 object，调用方需先对这些 object 调用 acquire 再使用句柄
+func eventHandles(events []*Event) ([]cl.Event, []*object) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+	handles := make([]cl.Event, len(events))
+	objs := make([]*object, len(events))
+	for i, e := range events {
+		handles[i] = e.handle
+		objs[i] = e.obj
+	}
+	return handles, objs
+}
@@ -0,0 +1,45 @@
+package ocl
+
+import (
+	"runtime"
+
+	"github.com/suanju/go-opencl/cl"
+)
+
+// MemObject 内存对象
+type MemObject struct {
+	obj    *object
+	handle cl.MemObject
+}
+
+// wrapMemObject 接管内存对象句柄
+func wrapMemObject(handle cl.MemObject) *MemObject {
+	m := &MemObject{
+		obj:    newObject("MemObject", func() error { return cl.ReleaseMemObject(handle) }),
+		handle: handle,
+	}
+	track(m, m.obj)
+	return m
+}
+
+// Handle 返回原始内存对象句柄
+func (m *MemObject) Handle() cl.MemObject {
+	return m.handle
+}
+
+// Size 返回内存对象的字节数
+func (m *MemObject) Size() (cl.Size, error) {
+	unlock, err := acquire(m.obj)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	size, err := cl.GetMemObjectSize(m.handle)
+	runtime.KeepAlive(m)
+	return size, err
+}
+
+// Close 释放内存对象，可重复调用
+func (m *MemObject) Close() error {
+	return m.obj.close()
+}
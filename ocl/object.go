@@ -0,0 +1,124 @@
+// Package ocl 在 cl 包的原始句柄之上提供面向对象的封装。
+//
+// 每个对象都实现 io.Closer，Close 可重复调用；可通过 EnableLeakDetection
+// 开启基于 finalizer 的泄漏检测，记录并释放未 Close 就被回收的对象。
+//
+// 对象的方法可以与 Close 并发调用：方法执行期间持有对象的读锁，Close 会等待正在执行的方法
+// （如 CommandQueue.Finish、Event.Wait）返回后再释放句柄，之后的调用返回 ErrClosed。
+package ocl
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+var (
+	_ io.Closer = (*Context)(nil)
+	_ io.Closer = (*CommandQueue)(nil)
+	_ io.Closer = (*Program)(nil)
+	_ io.Closer = (*Kernel)(nil)
+	_ io.Closer = (*MemObject)(nil)
+	_ io.Closer = (*Event)(nil)
+)
+
+// ErrClosed 对已经 Close 的对象调用方法时返回的错误
+var ErrClosed = errors.New("ocl: use of closed object")
+
+// leakLogger 泄漏检测日志函数，为 nil 表示未开启
+var leakLogger atomic.Pointer[func(format string, args ...any)]
+
+// EnableLeakDetection 开启泄漏检测
+// 之后创建的对象若未 Close 就被垃圾回收，会通过 logf 记录并释放底层句柄；logf 为 nil 时使用 log.Printf。
+// 使用 Handle() 取出原始句柄的代码需保证对象在句柄使用期间保持可达（如 runtime.KeepAlive）；
+// 封装对象自身的方法已经保证了这一点。
+func EnableLeakDetection(logf func(format string, args ...any)) {
+	if logf == nil {
+		logf = log.Printf
+	}
+	leakLogger.Store(&logf)
+}
+
+// DisableLeakDetection 关闭泄漏检测，对已创建的对象不生效
+func DisableLeakDetection() {
+	leakLogger.Store(nil)
+}
+
+// object 所有封装类型共享的释放逻辑
+// mu 的读锁由正在使用句柄的方法持有，写锁由 close 持有，保证句柄不会在使用中被释放。
+type object struct {
+	kind    string
+	mu      sync.RWMutex
+	closed  atomic.Bool
+	release func() error
+}
+
+// close 释放底层句柄，只有第一次调用会真正释放
+func (o *object) close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.closed.Load() {
+		return nil
+	}
+	o.closed.Store(true)
+	return o.release()
+}
+
+// isClosed 报告对象是否已经关闭
+func (o *object) isClosed() bool {
+	return o.closed.Load()
+}
+
+// acquire 对 objs 加读锁并检查是否已关闭，成功时返回解锁函数
+// 任一对象已关闭时释放已加的锁并返回包装了 ErrClosed 的错误，避免把已释放的句柄交给驱动。
+// 按地址顺序加锁并去重，避免多个 goroutine 以不同顺序加锁时与等待中的 Close 形成死锁。
+func acquire(objs ...*object) (func(), error) {
+	objs = slices.Clone(objs)
+	slices.SortFunc(objs, func(a, b *object) int {
+		return cmp.Compare(uintptr(unsafe.Pointer(a)), uintptr(unsafe.Pointer(b)))
+	})
+	objs = slices.Compact(objs)
+
+	unlock := func(locked []*object) {
+		for _, o := range locked {
+			o.mu.RUnlock()
+		}
+	}
+	for i, o := range objs {
+		o.mu.RLock()
+		if o.isClosed() {
+			unlock(objs[:i+1])
+			return nil, fmt.Errorf("%w: %s", ErrClosed, o.kind)
+		}
+	}
+	return func() { unlock(objs) }, nil
+}
+
+// track 在开启泄漏检测时为 owner 注册 finalizer
+func track[T any](owner *T, o *object) {
+	logf := leakLogger.Load()
+	if logf == nil {
+		return
+	}
+	runtime.SetFinalizer(owner, func(*T) {
+		if o.isClosed() {
+			return
+		}
+		(*logf)("ocl: %s garbage collected without Close", o.kind)
+		if err := o.close(); err != nil {
+			(*logf)("ocl: release leaked %s: %v", o.kind, err)
+		}
+	})
+}
+
+// newObject 创建释放逻辑；release 不能引用持有该 object 的封装对象，否则 finalizer 永远不会执行
+func newObject(kind string, release func() error) *object {
+	return &object{kind: kind, release: release}
+}
@@ -0,0 +1,100 @@
+package ocl
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAcquireAfterClose(t *testing.T) {
+	var released atomic.Int32
+	o := newObject("Test", func() error { released.Add(1); return nil })
+
+	if err := o.close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := o.close(); err != nil {
+		t.Fatal(err)
+	}
+	if n := released.Load(); n != 1 {
+		t.Fatalf("release called %d times, want 1", n)
+	}
+
+	if _, err := acquire(o); !errors.Is(err, ErrClosed) {
+		t.Fatalf("acquire after close = %v, want ErrClosed", err)
+	}
+}
+
+func TestCloseWaitsForInFlightCalls(t *testing.T) {
+	var released atomic.Bool
+	o := newObject("Test", func() error { released.Store(true); return nil })
+
+	unlock, err := acquire(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		o.close()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		t.Fatal("close returned while the object was in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if released.Load() {
+		t.Fatal("handle released while the object was in use")
+	}
+
+	unlock()
+	<-closed
+	if !released.Load() {
+		t.Fatal("handle not released after the call finished")
+	}
+}
+
+func TestAcquireDuplicateObjects(t *testing.T) {
+	o := newObject("Test", func() error { return nil })
+
+	unlock, err := acquire(o, o, o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	done := make(chan struct{})
+	go func() {
+		o.close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("unlock left a read lock held for a duplicated object")
+	}
+}
+
+func TestAcquireReleasesLocksOnError(t *testing.T) {
+	open := newObject("Open", func() error { return nil })
+	closed := newObject("Closed", func() error { return nil })
+	closed.close()
+
+	if _, err := acquire(open, closed); !errors.Is(err, ErrClosed) {
+		t.Fatalf("acquire = %v, want ErrClosed", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		open.close()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("failed acquire left a read lock held")
+	}
+}
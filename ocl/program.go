@@ -0,0 +1,172 @@
+package ocl
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/suanju/go-opencl/cl"
+)
+
+// Program 程序对象
+type Program struct {
+	obj    *object
+	handle cl.Program
+}
+
+// wrapProgram 接管程序句柄
+func wrapProgram(handle cl.Program) *Program {
+	p := &Program{
+		obj:    newObject("Program", func() error { return cl.ReleaseProgram(handle) }),
+		handle: handle,
+	}
+	track(p, p.obj)
+	return p
+}
+
+// Handle 返回原始程序句柄
+func (p *Program) Handle() cl.Program {
+	return p.handle
+}
+
+// Close 释放程序，可重复调用
+func (p *Program) Close() error {
+	return p.obj.close()
+}
+
+// Build 为设备构建程序，devices 为空时构建程序关联的全部设备
+func (p *Program) Build(options string, devices ...cl.DeviceID) error {
+	unlock, err := acquire(p.obj)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = cl.BuildProgram(p.handle, devices, options, nil, nil)
+	runtime.KeepAlive(p)
+	return err
+}
+
+// BuildLog 返回设备上的构建日志
+func (p *Program) BuildLog(device cl.DeviceID) (string, error) {
+	unlock, err := acquire(p.obj)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	log, err := cl.GetProgramBuildLog(p.handle, device)
+	runtime.KeepAlive(p)
+	return log, err
+}
+
+// CreateKernel 按名称创建内核
+func (p *Program) CreateKernel(name string) (*Kernel, error) {
+	unlock, err := acquire(p.obj)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.CreateKernel(p.handle, name)
+	runtime.KeepAlive(p)
+	if err != nil {
+		return nil, err
+	}
+	return wrapKernel(handle), nil
+}
+
+// Kernel 内核对象
+type Kernel struct {
+	obj    *object
+	handle cl.Kernel
+}
+
+// wrapKernel 接管内核句柄
+func wrapKernel(handle cl.Kernel) *Kernel {
+	k := &Kernel{
+		obj:    newObject("Kernel", func() error { return cl.ReleaseKernel(handle) }),
+		handle: handle,
+	}
+	track(k, k.obj)
+	return k
+}
+
+// Handle 返回原始内核句柄
+func (k *Kernel) Handle() cl.Kernel {
+	return k.handle
+}
+
+// Close 释放内核，可重复调用
+func (k *Kernel) Close() error {
+	return k.obj.close()
+}
+
+// Name 返回内核函数名
+func (k *Kernel) Name() (string, error) {
+	unlock, err := acquire(k.obj)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	name, err := cl.GetKernelFunctionName(k.handle)
+	runtime.KeepAlive(k)
+	return name, err
+}
+
+// NumArgs 返回内核参数个数
+func (k *Kernel) NumArgs() (cl.UInt, error) {
+	unlock, err := acquire(k.obj)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+	n, err := cl.GetKernelNumArgs(k.handle)
+	runtime.KeepAlive(k)
+	return n, err
+}
+
+// SetArg 设置内核参数，value 指向大小为 size 的参数值
+func (k *Kernel) SetArg(index cl.UInt, size cl.Size, value unsafe.Pointer) error {
+	unlock, err := acquire(k.obj)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = cl.SetKernelArg(k.handle, index, size, value)
+	runtime.KeepAlive(k)
+	return err
+}
+
+// SetArgMem 把内存对象设置为内核参数
+func (k *Kernel) SetArgMem(index cl.UInt, mem *MemObject) error {
+	unlock, err := acquire(k.obj, mem.obj)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	handle := mem.handle
+	err = cl.SetKernelArg(k.handle, index, cl.Size(unsafe.Sizeof(handle)), unsafe.Pointer(&handle))
+	runtime.KeepAlive(k)
+	runtime.KeepAlive(mem)
+	return err
+}
+
+// SetArgs 按顺序设置内核的全部参数，支持 cl.SetArgs 接受的类型以及 *MemObject
+func (k *Kernel) SetArgs(args ...any) error {
+	objs := []*object{k.obj}
+	converted := make([]any, len(args))
+	for i, arg := range args {
+		if mem, ok := arg.(*MemObject); ok {
+			objs = append(objs, mem.obj)
+			converted[i] = mem.handle
+		} else {
+			converted[i] = arg
+		}
+	}
+	unlock, err := acquire(objs...)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = cl.SetArgs(k.handle, converted...)
+	runtime.KeepAlive(k)
+	runtime.KeepAlive(args)
+	return err
+}
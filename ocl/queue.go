@@ -0,0 +1,141 @@
+package ocl
+
+import (
+	"runtime"
+	"unsafe"
+
+	"github.com/suanju/go-opencl/cl"
+)
+
+// CommandQueue 命令队列对象
+type CommandQueue struct {
+	obj    *object
+	handle cl.CommandQueue
+}
+
+// wrapCommandQueue 接管命令队列句柄
+func wrapCommandQueue(handle cl.CommandQueue) *CommandQueue {
+	q := &CommandQueue{
+		obj:    newObject("CommandQueue", func() error { return cl.ReleaseCommandQueue(handle) }),
+		handle: handle,
+	}
+	track(q, q.obj)
+	return q
+}
+
+// Handle 返回原始命令队列句柄
+func (q *CommandQueue) Handle() cl.CommandQueue {
+	return q.handle
+}
+
+// Close 释放命令队列，可重复调用
+func (q *CommandQueue) Close() error {
+	return q.obj.close()
+}
+
+// Flush 提交队列中的命令
+func (q *CommandQueue) Flush() error {
+	unlock, err := acquire(q.obj)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = cl.Flush(q.handle)
+	runtime.KeepAlive(q)
+	return err
+}
+
+// Finish 等待队列中的命令全部完成
+func (q *CommandQueue) Finish() error {
+	unlock, err := acquire(q.obj)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	err = cl.Finish(q.handle)
+	runtime.KeepAlive(q)
+	return err
+}
+
+// EnqueueRead 从内存对象读取 size 字节到 ptr
+func (q *CommandQueue) EnqueueRead(mem *MemObject, blocking bool, offset, size cl.Size, ptr unsafe.Pointer, waitList ...*Event) (*Event, error) {
+	waitHandles, waitObjs := eventHandles(waitList)
+	unlock, err := acquire(append(waitObjs, q.obj, mem.obj)...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.EnqueueReadBuffer(q.handle, mem.handle, boolArg(blocking), offset, size, ptr, waitHandles)
+	runtime.KeepAlive(q)
+	runtime.KeepAlive(mem)
+	runtime.KeepAlive(waitList)
+	if err != nil {
+		return nil, err
+	}
+	return wrapEvent(handle), nil
+}
+
+// EnqueueWrite 把 ptr 处的 size 字节写入内存对象
+func (q *CommandQueue) EnqueueWrite(mem *MemObject, blocking bool, offset, size cl.Size, ptr unsafe.Pointer, waitList ...*Event) (*Event, error) {
+	waitHandles, waitObjs := eventHandles(waitList)
+	unlock, err := acquire(append(waitObjs, q.obj, mem.obj)...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.EnqueueWriteBuffer(q.handle, mem.handle, boolArg(blocking), offset, size, ptr, waitHandles)
+	runtime.KeepAlive(q)
+	runtime.KeepAlive(mem)
+	runtime.KeepAlive(waitList)
+	if err != nil {
+		return nil, err
+	}
+	return wrapEvent(handle), nil
+}
+
+// EnqueueCopy 在两个内存对象之间复制 size 字节
+func (q *CommandQueue) EnqueueCopy(src, dst *MemObject, srcOffset, dstOffset, size cl.Size, waitList ...*Event) (*Event, error) {
+	waitHandles, waitObjs := eventHandles(waitList)
+	unlock, err := acquire(append(waitObjs, q.obj, src.obj, dst.obj)...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	handle, err := cl.EnqueueCopyBuffer(q.handle, src.handle, dst.handle, srcOffset, dstOffset, size, waitHandles)
+	runtime.KeepAlive(q)
+	runtime.KeepAlive(src)
+	runtime.KeepAlive(dst)
+	runtime.KeepAlive(waitList)
+	if err != nil {
+		return nil, err
+	}
+	return wrapEvent(handle), nil
+}
+
+// EnqueueNDRange 提交内核执行，globalOffset 和 localSize 可为 nil
+func (q *CommandQueue) EnqueueNDRange(kernel *Kernel, globalOffset, globalSize, localSize []cl.Size, waitList ...*Event) (*Event, error) {
+	waitHandles, waitObjs := eventHandles(waitList)
+	unlock, err := acquire(append(waitObjs, q.obj, kernel.obj)...)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	var handle cl.Event
+	err = cl.EnqueueNDRangeKernel(q.handle, kernel.handle, cl.UInt(len(globalSize)),
+		globalOffset, globalSize, localSize, waitHandles, &handle)
+	runtime.KeepAlive(q)
+	runtime.KeepAlive(kernel)
+	runtime.KeepAlive(waitList)
+	if err != nil {
+		return nil, err
+	}
+	return wrapEvent(handle), nil
+}
+
+// boolArg 把 Go 布尔值转换为 cl.Bool
+func boolArg(b bool) cl.Bool {
+	if b {
+		return cl.Bool(1)
+	}
+	return cl.Bool(0)
+}